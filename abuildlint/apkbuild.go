@@ -0,0 +1,465 @@
+package abuildlint
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"mvdan.cc/sh/syntax"
+	"strings"
+)
+
+const (
+	// Shell variant to use. Even though APKBUILDs are mostly
+	// written in POSIX shell we still use LangBash here to check
+	// for the `local` variable declaration keyword and other
+	// bashims permitted in APKBUILDs.
+	lang = syntax.LangBash
+
+	// Prefix used to indicate that a comment contains directives for
+	// the linter.
+	directivePrefix = " abuild-lint:"
+
+	// Directive used to disable checks for a single line.
+	disableDirective = "disable="
+
+	// Directive used to disable checks for the entire file.
+	disableFileDirective = "disable-file="
+)
+
+// Subpackage represents an entry of the subpackages metadata variable
+// which is of the form name[:split[:arch]].
+type Subpackage struct {
+	Name  string // Name of the subpackage
+	Split string // Name of the function creating the subpackage
+	Arch  string // Architecture of the subpackage, may be empty
+}
+
+// Source represents an entry of the source metadata variable which is
+// either of the form filename::url, url or filename.
+type Source struct {
+	Entry    string // Entry as declared in the APKBUILD
+	Filename string // Name of the file created for the entry
+	URL      string // Remote location of the file, empty if local
+}
+
+// Dependency represents an entry of a dependency metadata variable which
+// is of the form [!][prefix:]name[@tag][op version].
+type Dependency struct {
+	Entry    string // Entry as declared in the APKBUILD
+	Conflict bool   // Whether the entry declares a conflict
+	Prefix   string // Prefix of the name, e.g. so or cmd, may be empty
+	Name     string // Name of the package without prefix and tag
+	Tag      string // Repository tag, may be empty
+	Op       string // Version comparison operator, may be empty
+	Version  string // Version constraint, may be empty
+}
+
+// Checksum represents an entry of a checksum metadata variable which is
+// of the form digest filename.
+type Checksum struct {
+	Entry    string // Entry as declared in the APKBUILD
+	Digest   string // Hex encoded digest of the file
+	Filename string // Name of the file, empty if malformed
+}
+
+// badDirective represents a directive which couldn't be applied.
+type badDirective struct {
+	c      syntax.Comment // Comment containing the directive
+	format string         // Format of the message describing the problem
+	arg    string         // Argument of the message
+}
+
+// APKBUILD represents an Alpine Linux APKBUILD.
+type APKBUILD struct {
+	// Root node of the AST.
+	prog *syntax.File
+
+	// Source code of the APKBUILD.
+	src []byte
+
+	// Statically evaluated values of global variables, nil if the
+	// value of a variable can't be determined statically.
+	values map[string]*string
+
+	// Identifiers of checks disabled using directives, indexed by line
+	// number. Checks disabled for the entire file are stored at line
+	// zero.
+	disabled map[uint][]string
+
+	// Directives which are unknown or refer to unknown checks.
+	badDirectives []badDirective
+
+	// Globally declared comments.
+	Comments []syntax.Comment
+
+	// Global variable assignments excluding environment variables.
+	Assignments []syntax.Assign
+
+	// Declared functions.
+	Functions map[string]syntax.FuncDecl
+}
+
+// Parse reads and parses an Alpine Linux APKBUILD. The name will be
+// used in error messages emitted for this APKBUILD.
+func Parse(r io.Reader, name string) (*APKBUILD, error) {
+	parser := syntax.NewParser(syntax.KeepComments,
+		syntax.Variant(lang))
+
+	src, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	prog, err := parser.Parse(bytes.NewReader(src), name)
+	if err != nil {
+		return nil, err
+	}
+
+	apkbuild := APKBUILD{prog: prog, src: src}
+	apkbuild.Functions = make(map[string]syntax.FuncDecl)
+	apkbuild.disabled = make(map[uint][]string)
+	apkbuild.Walk(apkbuild.visit)
+	apkbuild.Walk(apkbuild.visitDirectives)
+	apkbuild.evaluate()
+
+	return &apkbuild, nil
+}
+
+// Name returns the name supplied to the parse function.
+func (a *APKBUILD) Name() string {
+	return a.prog.Name
+}
+
+// Source returns the source code of the APKBUILD.
+func (a *APKBUILD) Source() []byte {
+	return a.src
+}
+
+// Walk traverses the underlying AST of the APKBUILD in depth-first
+// order. It's just a wrapper function around syntax.Walk.
+func (a *APKBUILD) Walk(f func(syntax.Node) bool) {
+	syntax.Walk(a.prog, f)
+}
+
+func (a *APKBUILD) visit(node syntax.Node) bool {
+	switch x := node.(type) {
+	case *syntax.DeclClause:
+		return x.Variant.Value != "export"
+	case *syntax.FuncDecl:
+		a.Functions[x.Name.Value] = *x
+		return false // All nodes after this have local scope
+	case *syntax.Assign:
+		a.Assignments = append(a.Assignments, *x)
+		return true
+	case *syntax.Comment:
+		a.Comments = append(a.Comments, *x)
+		return true
+	default:
+		return true
+	}
+}
+
+func (a *APKBUILD) visitDirectives(node syntax.Node) bool {
+	c, ok := node.(*syntax.Comment)
+	if !ok || !strings.HasPrefix(c.Text, directivePrefix) {
+		return true
+	}
+
+	// Line directives declared at the end of a line apply to the line
+	// itself, those declared on a separate line apply to the
+	// following line. File directives apply to the entire file.
+	pos := c.Pos()
+	line := pos.Line()
+	if a.isBlank(line, int(pos.Col())-1) {
+		line++
+	}
+
+	for _, field := range strings.Fields(c.Text[len(directivePrefix):]) {
+		var list string
+		var scope uint
+		switch {
+		case strings.HasPrefix(field, disableDirective):
+			list, scope = field[len(disableDirective):], line
+		case strings.HasPrefix(field, disableFileDirective):
+			list, scope = field[len(disableFileDirective):], 0
+		default:
+			a.badDirectives = append(a.badDirectives,
+				badDirective{*c, unknownDirective, field})
+			continue
+		}
+
+		for _, name := range strings.Split(list, ",") {
+			if name == "" {
+				continue
+			}
+
+			check := FindCheck(name)
+			if check == nil {
+				a.badDirectives = append(a.badDirectives,
+					badDirective{*c, unknownDirectiveCheck, name})
+				continue
+			}
+			a.disabled[scope] = append(a.disabled[scope], check.Info().ID)
+		}
+	}
+
+	return true
+}
+
+// isBlank reports whether the first n bytes of the line with the given
+// line number only consist of white space characters.
+func (a *APKBUILD) isBlank(line uint, n int) bool {
+	lines := bytes.Split(a.src, []byte("\n"))
+	if line == 0 || line > uint(len(lines)) {
+		return true
+	}
+
+	l := lines[line-1]
+	if n < len(l) {
+		l = l[:n]
+	}
+
+	return len(bytes.TrimSpace(l)) == 0
+}
+
+// IsDisabled reports whether a check with one of the given identifiers
+// was disabled for the line with the given line number or for the
+// entire APKBUILD using a directive.
+func (a *APKBUILD) IsDisabled(line uint, ids ...string) bool {
+	for _, l := range []uint{0, line} {
+		for _, d := range a.disabled[l] {
+			if isIncluded(ids, d) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Subpackages returns the subpackages declared in the subpackages
+// metadata variable. If no split function is specified for a
+// subpackage, the split function is derived from the name of the
+// subpackage in the same way abuild(1) derives it.
+//
+// If the value of the variable can't be determined statically, all
+// global assignments to it are parsed instead, ignoring references to
+// the subpackages variable itself.
+func (a *APKBUILD) Subpackages() []Subpackage {
+	var subpkgs []Subpackage
+	if value, ok := a.Value("subpackages"); ok {
+		for _, field := range strings.Fields(value) {
+			subpkgs = append(subpkgs, parseSubpackage(field))
+		}
+		return subpkgs
+	}
+
+	for _, assign := range a.Assignments {
+		if assign.Name.Value != "subpackages" || assign.Value == nil {
+			continue
+		}
+
+		for _, field := range strings.Fields(a.rawWord(assign.Value)) {
+			if field == "$subpackages" || field == "${subpackages}" {
+				continue
+			}
+
+			subpkgs = append(subpkgs, parseSubpackage(field))
+		}
+	}
+
+	return subpkgs
+}
+
+// Sources returns the sources declared in the source metadata variable.
+// If the value of the variable can't be determined statically, the last
+// global assignment to it is parsed instead without expanding parameter
+// expansions.
+func (a *APKBUILD) Sources() []Source {
+	value, ok := a.Value("source")
+	if !ok {
+		assign := a.lastAssign("source")
+		if assign == nil || assign.Value == nil {
+			return nil
+		}
+		value = a.rawWord(assign.Value)
+	}
+
+	var sources []Source
+	for _, field := range strings.Fields(value) {
+		src := Source{Entry: field, Filename: field}
+		if i := strings.Index(field, "::"); i >= 0 {
+			src.Filename, src.URL = field[:i], field[i+2:]
+		} else if strings.Contains(field, "://") {
+			src.Filename = field[strings.LastIndex(field, "/")+1:]
+			src.URL = field
+		}
+
+		sources = append(sources, src)
+	}
+
+	return sources
+}
+
+// Checksums parses the last global assignment to the checksum metadata
+// variable with the given name and returns the declared checksums.
+func (a *APKBUILD) Checksums(varname string) []Checksum {
+	assign := a.lastAssign(varname)
+	if assign == nil || assign.Value == nil {
+		return nil
+	}
+
+	var checksums []Checksum
+	for _, line := range strings.Split(a.rawWord(assign.Value), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		sum := Checksum{Entry: line}
+		fields := strings.Fields(line)
+		if len(fields) == 2 {
+			sum.Digest, sum.Filename = fields[0], fields[1]
+		}
+
+		checksums = append(checksums, sum)
+	}
+
+	return checksums
+}
+
+// lastAssign returns the last global assignment to the variable with
+// the given name or nil if the variable isn't assigned globally.
+func (a *APKBUILD) lastAssign(varname string) *syntax.Assign {
+	for i := len(a.Assignments) - 1; i >= 0; i-- {
+		if a.Assignments[i].Name.Value == varname {
+			return &a.Assignments[i]
+		}
+	}
+
+	return nil
+}
+
+// parseSubpackage parses a single entry of the subpackages variable.
+func parseSubpackage(entry string) Subpackage {
+	parts := strings.SplitN(entry, ":", 3)
+
+	subpkg := Subpackage{Name: parts[0]}
+	if len(parts) > 1 && parts[1] != "" {
+		subpkg.Split = parts[1]
+	} else {
+		subpkg.Split = parts[0][strings.LastIndex(parts[0], "-")+1:]
+	}
+	if len(parts) > 2 {
+		subpkg.Arch = parts[2]
+	}
+
+	return subpkg
+}
+
+// Dependencies returns the entries of the dependency metadata variable
+// with the given name. It reports whether the value of the variable
+// could be determined statically.
+func (a *APKBUILD) Dependencies(varname string) ([]Dependency, bool) {
+	value, ok := a.Value(varname)
+	if !ok {
+		return nil, false
+	}
+
+	var deps []Dependency
+	for _, field := range strings.Fields(value) {
+		deps = append(deps, parseDependency(field))
+	}
+
+	return deps, true
+}
+
+// parseDependency parses a single entry of a dependency metadata
+// variable. The syntax of the components is not validated.
+func parseDependency(entry string) Dependency {
+	dep := Dependency{Entry: entry}
+
+	name := entry
+	if strings.HasPrefix(name, "!") {
+		dep.Conflict, name = true, name[1:]
+	}
+	if i := strings.IndexAny(name, "<>=~"); i >= 0 {
+		j := i + 1
+		for j < len(name) && strings.IndexByte("<>=~", name[j]) >= 0 {
+			j++
+		}
+		name, dep.Op, dep.Version = name[:i], name[i:j], name[j:]
+	}
+	if i := strings.Index(name, "@"); i >= 0 {
+		name, dep.Tag = name[:i], name[i+1:]
+	}
+	if i := strings.Index(name, ":"); i >= 0 {
+		dep.Prefix, name = name[:i], name[i+1:]
+	}
+	dep.Name = name
+
+	return dep
+}
+
+// Key returns the name of the dependency including its prefix.
+func (d Dependency) Key() string {
+	if d.Prefix == "" {
+		return d.Name
+	}
+	return d.Prefix + ":" + d.Name
+}
+
+// rawWord returns the source code of the given word with all quote
+// characters removed. Parameter expansions are not expanded.
+func (a *APKBUILD) rawWord(word *syntax.Word) string {
+	raw := string(a.src[word.Pos().Offset():word.End().Offset()])
+	return strings.NewReplacer("\"", "", "'", "").Replace(raw)
+}
+
+// IsGlobalVar checks if the supplied name responds to a global
+// variable declaration.
+func (a *APKBUILD) IsGlobalVar(varname string) bool {
+	for _, assignment := range a.Assignments {
+		if assignment.Name.Value == varname {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsUnusedVar checks if the variable with the supplied name is unused
+// in the APKBUILD. It also returns true if the given variable is an
+// environment variable.
+func (a *APKBUILD) IsUnusedVar(varname string) bool {
+	ret := true
+	a.Walk(func(node syntax.Node) bool {
+		switch x := node.(type) {
+		case *syntax.DeclClause:
+			if x.Variant.Value != "export" {
+				return true
+			}
+
+			for _, a := range x.Assigns {
+				if a.Name.Value == varname {
+					ret = false
+					return false
+				}
+			}
+		case *syntax.SglQuoted:
+			if x.Dollar && x.Value == varname {
+				ret = false
+				return false
+			}
+		case *syntax.ParamExp:
+			if x.Param.Value == varname {
+				ret = false
+				return false
+			}
+		}
+
+		return true
+	})
+
+	return ret
+}
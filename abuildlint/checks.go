@@ -0,0 +1,323 @@
+package abuildlint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Check is implemented by all linter checks. Each check can be referred
+// to using either its stable identifier or its stable name. Checks
+// implemented outside of this package are made available using
+// Register.
+type Check interface {
+	// Info returns the metadata of the check.
+	Info() CheckInfo
+
+	// Run performs the check on the APKBUILD of the given linter.
+	// Style violations are recorded using Linter.Reportf.
+	Run(l *Linter)
+}
+
+// CheckInfo describes a single linter check.
+type CheckInfo struct {
+	ID          string   // Stable identifier, e.g. AL001
+	Name        string   // Stable human readable name
+	Description string   // Short description of the check
+	Severity    Severity // Default severity of violations
+	Fixable     bool     // Whether violations can be fixed
+}
+
+// builtinCheck is a check implemented by a method of the linter.
+type builtinCheck struct {
+	CheckInfo
+	fn func(*Linter) // Function performing the check, may be nil
+}
+
+// Info returns the metadata of the check.
+func (c *builtinCheck) Info() CheckInfo {
+	return c.CheckInfo
+}
+
+// Run performs the check by calling the linter method, if any.
+func (c *builtinCheck) Run(l *Linter) {
+	if c.fn != nil {
+		c.fn(l)
+	}
+}
+
+// Severity describes how severe a style violation is.
+type Severity int
+
+const (
+	// Violation is merely informational.
+	SeverityInfo Severity = iota
+
+	// Violation should be fixed but is not critical.
+	SeverityWarning
+
+	// Violation must be fixed.
+	SeverityError
+)
+
+// Names of all severities, indexed by severity.
+var severities = []string{"info", "warning", "error"}
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	if !s.valid() {
+		return fmt.Sprintf("Severity(%d)", int(s))
+	}
+
+	return severities[s]
+}
+
+// valid reports whether the severity is one of the defined severities.
+func (s Severity) valid() bool {
+	return s >= 0 && int(s) < len(severities)
+}
+
+// ParseSeverity returns the severity with the given name.
+func ParseSeverity(name string) (Severity, error) {
+	for n, s := range severities {
+		if s == name {
+			return Severity(n), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown severity %q", name)
+}
+
+// MarshalText encodes the severity as its name.
+func (s Severity) MarshalText() ([]byte, error) {
+	if !s.valid() {
+		return nil, fmt.Errorf("invalid severity %d", int(s))
+	}
+
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText decodes a severity from its name.
+func (s *Severity) UnmarshalText(text []byte) error {
+	sev, err := ParseSeverity(string(text))
+	if err != nil {
+		return err
+	}
+
+	*s = sev
+	return nil
+}
+
+var (
+	checkParse = &builtinCheck{CheckInfo{"AL000", "parse-error",
+		"APKBUILD can be read and parsed",
+		SeverityError, false}, nil}
+	checkComments = &builtinCheck{CheckInfo{"AL001", "comment-prefix",
+		"Comments start with a space",
+		SeverityWarning, true}, (*Linter).lintComments}
+	checkAddressComments = &builtinCheck{CheckInfo{"AL002", "address-comments",
+		"Maintainer and contributor comments are well-formed",
+		SeverityError, true}, (*Linter).lintMaintainerAndContributors}
+	checkGlobalVariables = &builtinCheck{CheckInfo{"AL003", "global-variable",
+		"Custom global variables start with a single underscore",
+		SeverityError, false}, (*Linter).lintGlobalVariables}
+	checkGlobalCmdSubsts = &builtinCheck{CheckInfo{"AL004", "global-cmdsubst",
+		"Command substitutions are not used outside of functions",
+		SeverityError, false}, (*Linter).lintGlobalCmdSubsts}
+	checkLocalVariables = &builtinCheck{CheckInfo{"AL005", "local-variable",
+		"Variables inside functions are declared local",
+		SeverityError, false}, (*Linter).lintLocalVariables}
+	checkUnusedVariables = &builtinCheck{CheckInfo{"AL006", "unused-variable",
+		"Declared variables are used",
+		SeverityWarning, false}, (*Linter).lintUnusedVariables}
+	checkParamExpression = &builtinCheck{CheckInfo{"AL007", "param-expansion",
+		"Long parameter expansions can't be replaced by short ones",
+		SeverityWarning, true}, (*Linter).lintParamExpression}
+	checkMetadataPlacement = &builtinCheck{CheckInfo{"AL008", "metadata-placement",
+		"Metadata variables are declared before or after all functions",
+		SeverityError, true}, (*Linter).lintMetadataPlacement}
+	checkRequiredMetadata = &builtinCheck{CheckInfo{"AL009", "required-metadata",
+		"Required metadata variables are defined",
+		SeverityError, false}, (*Linter).lintRequiredMetadata}
+	checkFunctionOrder = &builtinCheck{CheckInfo{"AL010", "function-order",
+		"Package functions are declared in invocation order",
+		SeverityWarning, false}, (*Linter).lintFunctionOrder}
+	checkBashisms = &builtinCheck{CheckInfo{"AL011", "bashism",
+		"Forbidden bash extensions are not used",
+		SeverityError, false}, (*Linter).lintBashisms}
+	checkSubpackages = &builtinCheck{CheckInfo{"AL012", "split-function",
+		"Split functions of subpackages are declared and used",
+		SeverityError, false}, (*Linter).lintSubpackages}
+	checkChecksums = &builtinCheck{CheckInfo{"AL013", "checksums",
+		"Checksums correspond to the declared sources",
+		SeverityError, false}, (*Linter).lintChecksums}
+	checkDeprecatedChecksums = &builtinCheck{CheckInfo{"AL014", "deprecated-checksum",
+		"Only a single non-deprecated checksum variable is defined",
+		SeverityWarning, false}, (*Linter).lintDeprecatedChecksums}
+	checkPackageFields = &builtinCheck{CheckInfo{"AL015", "package-fields",
+		"Package name, version and release are well-formed",
+		SeverityError, false}, (*Linter).lintPackageFields}
+	checkLicense = &builtinCheck{CheckInfo{"AL016", "license",
+		"License is a valid SPDX license expression",
+		SeverityError, false}, (*Linter).lintLicense}
+	checkArch = &builtinCheck{CheckInfo{"AL017", "arch",
+		"Architectures are known and listed only once",
+		SeverityError, false}, (*Linter).lintArch}
+	checkPkgdesc = &builtinCheck{CheckInfo{"AL018", "pkgdesc",
+		"Package description follows the style guidelines",
+		SeverityWarning, false}, (*Linter).lintPkgdesc}
+	checkURLs = &builtinCheck{CheckInfo{"AL019", "url",
+		"URLs are well-formed, use https and refer to $pkgver",
+		SeverityWarning, false}, (*Linter).lintURLs}
+	checkLocalSources = &builtinCheck{CheckInfo{"AL020", "local-source",
+		"Local sources exist next to the APKBUILD",
+		SeverityError, false}, (*Linter).lintLocalSources}
+	checkDepends = &builtinCheck{CheckInfo{"AL021", "depends",
+		"Dependencies are well-formed and listed only once",
+		SeverityWarning, false}, (*Linter).lintDepends}
+	checkOptions = &builtinCheck{CheckInfo{"AL022", "options",
+		"Options are supported by abuild and listed only once",
+		SeverityError, false}, (*Linter).lintOptions}
+	checkDirectives = &builtinCheck{CheckInfo{"AL023", "directive",
+		"Directives are known and only refer to known checks",
+		SeverityError, false}, (*Linter).lintDirectives}
+)
+
+// Registered checks sorted by identifier.
+var registry = []Check{
+	checkParse,
+	checkComments,
+	checkAddressComments,
+	checkGlobalVariables,
+	checkGlobalCmdSubsts,
+	checkLocalVariables,
+	checkUnusedVariables,
+	checkParamExpression,
+	checkMetadataPlacement,
+	checkRequiredMetadata,
+	checkFunctionOrder,
+	checkBashisms,
+	checkSubpackages,
+	checkChecksums,
+	checkDeprecatedChecksums,
+	checkPackageFields,
+	checkLicense,
+	checkArch,
+	checkPkgdesc,
+	checkURLs,
+	checkLocalSources,
+	checkDepends,
+	checkOptions,
+	checkDirectives,
+}
+
+// Register makes the given check available to the linter. Register is
+// not safe for concurrent use and meant to be called from init
+// functions. It panics if the check lacks an identifier or a name, if
+// either of them is already used by a registered check or if its
+// severity is invalid.
+func Register(c Check) {
+	info := c.Info()
+	if info.ID == "" || info.Name == "" {
+		panic("abuildlint: check without identifier or name")
+	} else if !info.Severity.valid() {
+		panic(fmt.Sprintf("abuildlint: check %s has invalid severity %d",
+			info.ID, int(info.Severity)))
+	}
+	if FindCheck(info.ID) != nil || FindCheck(info.Name) != nil {
+		panic(fmt.Sprintf("abuildlint: check %s (%s) registered twice",
+			info.ID, info.Name))
+	}
+
+	i := sort.Search(len(registry), func(i int) bool {
+		return registry[i].Info().ID > info.ID
+	})
+	registry = append(registry, nil)
+	copy(registry[i+1:], registry[i:])
+	registry[i] = c
+}
+
+// Checks returns all registered checks sorted by identifier. Checks
+// are performed in this order.
+func Checks() []Check {
+	return append([]Check(nil), registry...)
+}
+
+// FindCheck returns the check with the given identifier or name. If no
+// such check exists nil is returned.
+func FindCheck(idOrName string) Check {
+	for _, c := range registry {
+		info := c.Info()
+		if info.ID == idOrName || info.Name == idOrName {
+			return c
+		}
+	}
+
+	return nil
+}
+
+// ParseChecks parses the given list of check identifiers or names and
+// returns the corresponding checks. Empty list elements are ignored.
+func ParseChecks(list []string) ([]Check, error) {
+	var ret []Check
+	for _, elem := range list {
+		elem = strings.TrimSpace(elem)
+		if elem == "" {
+			continue
+		}
+
+		c := FindCheck(elem)
+		if c == nil {
+			return nil, fmt.Errorf("unknown check %q", elem)
+		}
+		ret = append(ret, c)
+	}
+
+	return ret, nil
+}
+
+// SelectChecks returns the identifiers of all checks which should be
+// performed given a list of checks to enable and a list of checks to
+// disable. If the list of checks to enable is empty, all checks are
+// enabled. Parse errors are always reported, hence the parse-error
+// check can neither be enabled nor disabled.
+func SelectChecks(enable, disable []string) (map[string]bool, error) {
+	enabled := make(map[string]bool)
+	cs, err := parseSelected(enable)
+	if err != nil {
+		return nil, err
+	}
+	if len(cs) == 0 {
+		cs = registry
+	}
+	for _, c := range cs {
+		enabled[c.Info().ID] = true
+	}
+
+	cs, err = parseSelected(disable)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cs {
+		delete(enabled, c.Info().ID)
+	}
+
+	return enabled, nil
+}
+
+// parseSelected is like ParseChecks but rejects the parse-error check.
+func parseSelected(list []string) ([]Check, error) {
+	cs, err := ParseChecks(list)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range cs {
+		if c == Check(checkParse) {
+			return nil, fmt.Errorf("check %q is always performed",
+				checkParse.Name)
+		}
+	}
+
+	return cs, nil
+}
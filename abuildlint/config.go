@@ -0,0 +1,279 @@
+package abuildlint
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const (
+	// File name used for abuild-lint configuration files.
+	configfn = ".abuild-lint.toml"
+
+	// Name of the configuration table for check severities.
+	severityTable = "severity."
+)
+
+// Config represents an abuild-lint configuration file. Configuration
+// files are written in a subset of TOML supporting strings, booleans,
+// arrays of strings and tables.
+type Config struct {
+	// Name of the configuration file.
+	Name string
+
+	// Checks which should be performed, all if empty.
+	Enable []string
+
+	// Checks which should not be performed.
+	Disable []string
+
+	// Additional metadata variables picked up by abuild(1).
+	Metadata []string
+
+	// Additional package functions called by abuild(1) after all
+	// default package functions in the given order.
+	Functions []string
+
+	// Additional architectures supported by abuild(1).
+	Arches []string
+
+	// Whether entries of dependency metadata variables must be sorted.
+	SortDepends bool
+
+	// Severities overriding the default severity of checks, indexed
+	// by check identifier.
+	Severity map[string]Severity
+}
+
+// FindConfig searches for a configuration file in the given directory
+// and all its parent directories. It returns the name of the first
+// configuration file found or an empty string if there is none.
+func FindConfig(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		fn := filepath.Join(dir, configfn)
+		if exists(fn) {
+			return fn
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadConfig reads and parses the configuration file with the given
+// name.
+func LoadConfig(fn string) (*Config, error) {
+	file, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return ParseConfig(file, fn)
+}
+
+// ParseConfig parses a configuration file. The name will be used in
+// error messages emitted for this configuration file.
+func ParseConfig(r io.Reader, name string) (*Config, error) {
+	values, err := parseTOML(r, name)
+	if err != nil {
+		return nil, err
+	}
+
+	config := Config{Name: name, Severity: make(map[string]Severity)}
+	fields := map[string]*[]string{
+		"enable":    &config.Enable,
+		"disable":   &config.Disable,
+		"metadata":  &config.Metadata,
+		"functions": &config.Functions,
+		"arches":    &config.Arches,
+	}
+	flags := map[string]*bool{
+		"sort-depends": &config.SortDepends,
+	}
+
+	for key, value := range values {
+		if strings.HasPrefix(key, severityTable) {
+			err := config.parseSeverity(key[len(severityTable):], value)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %s", name, err)
+			}
+			continue
+		}
+
+		if flag, ok := flags[key]; ok {
+			b, ok := value.(bool)
+			if !ok {
+				return nil, fmt.Errorf("%s: value of %q must be a boolean", name, key)
+			}
+			*flag = b
+			continue
+		}
+
+		field, ok := fields[key]
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown key %q", name, key)
+		}
+
+		list, ok := value.([]string)
+		if !ok {
+			return nil, fmt.Errorf("%s: value of %q must be an array", name, key)
+		}
+		*field = list
+	}
+
+	if _, err := SelectChecks(config.Enable, config.Disable); err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+
+	return &config, nil
+}
+
+// parseSeverity parses the severity for the check with the given
+// identifier or name from the given configuration value.
+func (c *Config) parseSeverity(check string, value interface{}) error {
+	name, ok := value.(string)
+	if !ok {
+		return fmt.Errorf("severity of %q must be a string", check)
+	}
+
+	sev, err := ParseSeverity(name)
+	if err != nil {
+		return err
+	}
+
+	chk := FindCheck(check)
+	if chk == nil {
+		return fmt.Errorf("unknown check %q", check)
+	} else if chk == Check(checkParse) {
+		return fmt.Errorf("severity of %q can't be changed", check)
+	}
+
+	c.Severity[chk.Info().ID] = sev
+	return nil
+}
+
+// parseTOML parses the TOML subset supported by configuration files.
+// Keys declared in a table are prefixed with the table name followed
+// by a dot. Values are either of type string, bool or []string.
+func parseTOML(r io.Reader, name string) (map[string]interface{}, error) {
+	values := make(map[string]interface{})
+	scanner := bufio.NewScanner(r)
+
+	var table string
+	for lineno := 1; scanner.Scan(); lineno++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if !strings.HasSuffix(line, "]") {
+				return nil, fmt.Errorf("%s:%d: invalid table header", name, lineno)
+			}
+			table = strings.TrimSpace(line[1:len(line)-1]) + "."
+			continue
+		}
+
+		sep := strings.Index(line, "=")
+		if sep == -1 {
+			return nil, fmt.Errorf("%s:%d: expected key/value pair", name, lineno)
+		}
+
+		key := strings.Trim(strings.TrimSpace(line[:sep]), "\"")
+		raw := strings.TrimSpace(line[sep+1:])
+
+		// Arrays may span multiple lines.
+		for strings.HasPrefix(raw, "[") && !strings.HasSuffix(raw, "]") {
+			if !scanner.Scan() {
+				return nil, fmt.Errorf("%s:%d: unterminated array", name, lineno)
+			}
+			lineno++
+			raw += " " + strings.TrimSpace(stripComment(scanner.Text()))
+		}
+
+		value, err := parseTOMLValue(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", name, lineno, err)
+		}
+
+		if _, ok := values[table+key]; ok {
+			return nil, fmt.Errorf("%s:%d: duplicate key %q", name, lineno, key)
+		}
+		values[table+key] = value
+	}
+
+	return values, scanner.Err()
+}
+
+// parseTOMLValue parses a single TOML string, boolean or array of
+// strings.
+func parseTOMLValue(raw string) (interface{}, error) {
+	switch {
+	case raw == "true" || raw == "false":
+		return raw == "true", nil
+	case strings.HasPrefix(raw, "\""):
+		return strconv.Unquote(raw)
+	case strings.HasPrefix(raw, "'"):
+		if len(raw) < 2 || !strings.HasSuffix(raw, "'") {
+			return nil, fmt.Errorf("invalid literal string %s", raw)
+		}
+		return raw[1 : len(raw)-1], nil
+	case strings.HasPrefix(raw, "["):
+		list := []string{}
+		for _, elem := range strings.Split(raw[1:len(raw)-1], ",") {
+			elem = strings.TrimSpace(elem)
+			if elem == "" {
+				continue
+			}
+
+			v, err := parseTOMLValue(elem)
+			if err != nil {
+				return nil, err
+			}
+			s, ok := v.(string)
+			if !ok {
+				return nil, fmt.Errorf("array elements must be strings")
+			}
+			list = append(list, s)
+		}
+		return list, nil
+	default:
+		return nil, fmt.Errorf("unsupported value %s", raw)
+	}
+}
+
+// stripComment removes a trailing comment from the given line. Comment
+// characters inside of strings are ignored.
+func stripComment(line string) string {
+	var quote rune
+	var escaped bool
+	for i, r := range line {
+		switch {
+		case escaped:
+			escaped = false
+		case quote == '"' && r == '\\':
+			escaped = true
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote == 0 && (r == '"' || r == '\''):
+			quote = r
+		case quote == 0 && r == '#':
+			return line[:i]
+		}
+	}
+
+	return line
+}
@@ -0,0 +1,65 @@
+package abuildlint
+
+const (
+	invalidGlobalVar      = "Custom global variable %q doesn't start with a single '_'"
+	variableUnused        = "Variable %q is unused"
+	nonLocalVariable      = "Variable %q was not declared using the local keyword"
+	wrongFuncOrder        = "Function %q should be declared after function %q"
+	trivialLongParamExp   = "Parameter Expansion \"${%s}\" can be replaced by a short Expansion \"$%s\""
+	metadataAfterFunc     = "Variable %q should be declared after the last function declaration"
+	metadataBeforeFunc    = "Variable %q should be declared before the first function declaration"
+	forbiddenBashism      = "Usage of bash extension %q is not allowed"
+	missingMetadata       = "Variable %q is required but wasn't defined"
+	missingSplitFunc      = "Split function %q of subpackage %q is not declared"
+	unusedSplitFunc       = "Function %q is neither a metadata function nor used by a subpackage"
+	missingChecksum       = "Source %q has no checksum in %q"
+	unknownChecksum       = "Checksum for %q doesn't correspond to a source"
+	wrongChecksumOrder    = "Checksum for %q should be declared before checksum for %q"
+	malformedChecksum     = "Checksum entry %q is malformed"
+	invalidDigest         = "Checksum for %q is not a valid hex encoded digest of length %d"
+	deprecatedChecksum    = "Checksum variable %q is deprecated, use %q instead"
+	multipleChecksums     = "Checksum variable %q is defined in addition to %q"
+	invalidPkgname        = "Package name %q must only consist of lowercase letters, digits, '.', '_', '+' and '-'"
+	invalidPkgver         = "Package version %q doesn't conform to the apk version format"
+	invalidPkgrel         = "Package release %q is not a non-negative integer"
+	invalidLicenseExpr    = "License %q is not a valid SPDX license expression: %s"
+	unknownLicense        = "License %q is not a known SPDX license identifier"
+	unknownException      = "License exception %q is not a known SPDX exception identifier"
+	legacyLicense         = "License %q is a legacy name, use %q instead"
+	deprecatedLicense     = "License %q is a deprecated SPDX license identifier"
+	deprecatedException   = "License exception %q is a deprecated SPDX exception identifier"
+	unknownArch           = "Architecture %q is not a known architecture"
+	negatedArch           = "Architecture %q can't be negated"
+	duplicateArch         = "Architecture %q is listed multiple times"
+	pkgdescTooLong        = "Package description is longer than %d characters"
+	pkgdescPeriod         = "Package description shouldn't end with a period"
+	pkgdescPkgname        = "Package description shouldn't start with the package name"
+	pkgdescArticle        = "Package description shouldn't start with an article"
+	pkgdescSpace          = "Package description shouldn't start or end with whitespace"
+	malformedURL          = "URL %q is malformed"
+	insecureURL           = "URL %q should use https instead of http"
+	hardcodedVersion      = "URL %q contains the package version, use $pkgver instead"
+	missingLocalSource    = "Local source %q doesn't exist"
+	malformedDepend       = "Dependency %q in %q is malformed"
+	duplicateDepend       = "Dependency %q is listed multiple times in %q"
+	redundantDepend       = "Dependency %q is listed in %q and %q"
+	selfDepend            = "Package depends on itself in %q"
+	unsortedDepends       = "Dependency %q in %q should be listed before %q"
+	unknownOption         = "Option %q is not supported by abuild"
+	duplicateOption       = "Option %q is listed multiple times"
+	undocumentedNoCheck   = "Option \"!check\" requires a comment explaining why tests are disabled"
+	unknownDirective      = "Directive %q is unknown"
+	unknownDirectiveCheck = "Directive refers to unknown check %q"
+	noarchCombined        = "Architecture \"noarch\" can't be combined with other architectures"
+
+	badCommentPrefix      = "Comment doesn't start with a space"
+	missingMaintainer     = "Maintainer is missing"
+	missingAddress        = "Comment is missing an RFC 5322 address"
+	noAddressSeparator    = "Mail address should be seperated from prefix with a space"
+	invalidAddress        = "Mail address doesn't conform to RFC 5322"
+	tooManyMaintainers    = "Only one maintainer can be specified"
+	cmdSubstInGlobalVar   = "$(…) shouldn't be used in global variables"
+	maintainerAfterAssign = "The maintainer comment should be declared before any assignment"
+	repeatedAddrComment   = "Contributor comment with this RFC 5322 has already been defined"
+	wrongAddrCommentOrder = "Contributor comment should be defined before the maintainer comment"
+)
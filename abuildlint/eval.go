@@ -0,0 +1,220 @@
+package abuildlint
+
+import (
+	"mvdan.cc/sh/syntax"
+	"regexp"
+	"strings"
+)
+
+// evaluate statically evaluates all global variable assignments of the
+// APKBUILD in declaration order. Assignments which are not executed
+// unconditionally or whose value can't be determined statically mark
+// the assigned variable as unknown.
+func (a *APKBUILD) evaluate() {
+	a.values = make(map[string]*string)
+	for _, stmt := range a.prog.Stmts {
+		call, ok := stmt.Cmd.(*syntax.CallExpr)
+		if !ok || len(call.Args) > 0 {
+			a.invalidate(stmt)
+			continue
+		}
+
+		for _, assign := range call.Assigns {
+			a.assign(assign)
+		}
+	}
+}
+
+// assign evaluates a single global variable assignment.
+func (a *APKBUILD) assign(assign *syntax.Assign) {
+	name := assign.Name.Value
+	if assign.Array != nil || assign.Index != nil {
+		a.values[name] = nil
+		return
+	}
+
+	var value string
+	if assign.Value != nil {
+		v, ok := a.eval(assign.Value)
+		if !ok {
+			a.values[name] = nil
+			return
+		}
+		value = v
+	}
+
+	// Appending to a variable which is not assigned globally yields
+	// an unknown value since it might be set by abuild(1).
+	if assign.Append {
+		old := a.values[name]
+		if old == nil {
+			a.values[name] = nil
+			return
+		}
+		value = *old + value
+	}
+
+	a.values[name] = &value
+}
+
+// invalidate marks all global variables assigned in the given statement
+// as unknown. Environment variables and function declarations are
+// ignored.
+func (a *APKBUILD) invalidate(stmt *syntax.Stmt) {
+	syntax.Walk(stmt, func(node syntax.Node) bool {
+		switch x := node.(type) {
+		case *syntax.DeclClause:
+			return x.Variant.Value != "export"
+		case *syntax.FuncDecl:
+			return false
+		case *syntax.Assign:
+			a.values[x.Name.Value] = nil
+		}
+
+		return true
+	})
+}
+
+// eval statically evaluates the given word. It reports whether the
+// value of the word could be determined.
+func (a *APKBUILD) eval(word *syntax.Word) (string, bool) {
+	return a.evalParts(word.Parts)
+}
+
+// evalParts statically evaluates the given word parts and concatenates
+// their values.
+func (a *APKBUILD) evalParts(parts []syntax.WordPart) (string, bool) {
+	var value string
+	for _, part := range parts {
+		var v string
+		var ok bool
+
+		switch x := part.(type) {
+		case *syntax.Lit:
+			v, ok = strings.Replace(x.Value, "\\\n", "", -1), true
+		case *syntax.SglQuoted:
+			v, ok = x.Value, !x.Dollar
+		case *syntax.DblQuoted:
+			v, ok = a.evalParts(x.Parts)
+		case *syntax.ParamExp:
+			v, ok = a.evalParamExp(x)
+		}
+
+		if !ok {
+			return "", false
+		}
+		value += v
+	}
+
+	return value, true
+}
+
+// evalParamExp statically evaluates the given parameter expansion.
+// Only simple expansions, default values and prefix or suffix removal
+// are supported. Expansions of variables which are not assigned
+// globally are unknown, since they might be set by abuild(1) or the
+// environment.
+func (a *APKBUILD) evalParamExp(exp *syntax.ParamExp) (string, bool) {
+	if exp.Excl || exp.Length || exp.Width || exp.Index != nil ||
+		exp.Slice != nil || exp.Repl != nil {
+		return "", false
+	}
+
+	ptr := a.values[exp.Param.Value]
+	if ptr == nil {
+		return "", false
+	}
+
+	value := *ptr
+	if exp.Exp == nil {
+		return value, true
+	}
+
+	arg := ""
+	if exp.Exp.Word != nil {
+		v, ok := a.eval(exp.Exp.Word)
+		if !ok {
+			return "", false
+		}
+		arg = v
+	}
+
+	switch exp.Exp.Op {
+	case syntax.SubstMinus:
+		return value, true
+	case syntax.SubstColMinus:
+		if value == "" {
+			return arg, true
+		}
+		return value, true
+	case syntax.SubstPlus:
+		return arg, true
+	case syntax.SubstColPlus:
+		if value != "" {
+			return arg, true
+		}
+		return "", true
+	case syntax.RemSmallSuffix, syntax.RemLargeSuffix,
+		syntax.RemSmallPrefix, syntax.RemLargePrefix:
+		return trimPattern(value, arg, exp.Exp.Op)
+	default:
+		return "", false
+	}
+}
+
+// trimPattern removes the smallest or largest prefix or suffix matching
+// the given shell pattern from the given value.
+func trimPattern(value, pattern string, op syntax.ParExpOperator) (string, bool) {
+	expr, err := syntax.TranslatePattern(pattern, true)
+	if err != nil {
+		return "", false
+	}
+	re, err := regexp.Compile("^(?s:" + expr + ")$")
+	if err != nil {
+		return "", false
+	}
+
+	n := len(value)
+	switch op {
+	case syntax.RemSmallSuffix:
+		for i := n; i >= 0; i-- {
+			if re.MatchString(value[i:]) {
+				return value[:i], true
+			}
+		}
+	case syntax.RemLargeSuffix:
+		for i := 0; i <= n; i++ {
+			if re.MatchString(value[i:]) {
+				return value[:i], true
+			}
+		}
+	case syntax.RemSmallPrefix:
+		for i := 0; i <= n; i++ {
+			if re.MatchString(value[:i]) {
+				return value[i:], true
+			}
+		}
+	case syntax.RemLargePrefix:
+		for i := n; i >= 0; i-- {
+			if re.MatchString(value[:i]) {
+				return value[i:], true
+			}
+		}
+	}
+
+	return value, true
+}
+
+// Value returns the value of the global variable with the given name
+// as determined by statically evaluating all global assignments. It
+// reports whether the value could be determined. Variables which are
+// not assigned globally, e.g. variables set by abuild(1) itself, are
+// considered unknown.
+func (a *APKBUILD) Value(varname string) (string, bool) {
+	ptr := a.values[varname]
+	if ptr == nil {
+		return "", false
+	}
+
+	return *ptr, true
+}
@@ -0,0 +1,209 @@
+package abuildlint
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+const (
+	// Amount of unchanged lines shown around changes in a diff.
+	diffContext = 3
+)
+
+// Edit describes a replacement of the bytes in the range [Start, End)
+// of an APKBUILD with the string New.
+type Edit struct {
+	Start uint   `json:"start"` // Offset of the first replaced byte
+	End   uint   `json:"end"`   // Offset after the last replaced byte
+	New   string `json:"new"`   // Replacement text
+}
+
+// ApplyEdits applies the given edits to the given source code. All
+// bytes not covered by an edit are preserved. Edits overlapping with a
+// previous edit are skipped.
+func ApplyEdits(src []byte, edits []Edit) []byte {
+	sorted := make([]Edit, len(edits))
+	copy(sorted, edits)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Start < sorted[j].Start
+	})
+
+	var buf bytes.Buffer
+	var offset uint
+	for _, e := range sorted {
+		if e.Start < offset || e.End > uint(len(src)) {
+			continue // overlapping or invalid edit
+		}
+
+		buf.Write(src[offset:e.Start])
+		buf.WriteString(e.New)
+		offset = e.End
+	}
+	buf.Write(src[offset:])
+
+	return buf.Bytes()
+}
+
+// lineOp describes a single line of a diff.
+type lineOp struct {
+	k byte   // Kind of the line: ' ', '-' or '+'
+	s string // Content of the line including the newline
+}
+
+// WriteDiff writes a unified diff between the given old and new version
+// of the file with the given name to the given writer.
+func WriteDiff(w io.Writer, name string, old, new []byte) error {
+	ops := diffLines(splitLines(old), splitLines(new))
+
+	var hunks [][2]int
+	for i := 0; i < len(ops); i++ {
+		if ops[i].k == ' ' {
+			continue
+		}
+
+		start := i - diffContext
+		if start < 0 {
+			start = 0
+		}
+
+		// Extend the hunk until diffContext unchanged lines follow
+		// the last change.
+		end, same := i, 0
+		for end < len(ops) && same < 2*diffContext {
+			if ops[end].k == ' ' {
+				same++
+			} else {
+				same = 0
+			}
+			end++
+		}
+		if same > diffContext {
+			end -= same - diffContext
+		}
+
+		hunks = append(hunks, [2]int{start, end})
+		i = end - 1
+	}
+
+	if len(hunks) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	fn := filepath.ToSlash(filepath.Clean(name))
+	fmt.Fprintf(&buf, "--- %s\n+++ %s\n", path.Join("a", fn), path.Join("b", fn))
+
+	var oldLine, newLine, pos int
+	for _, h := range hunks {
+		for ; pos < h[0]; pos++ {
+			oldLine, newLine = advance(ops[pos], oldLine, newLine)
+		}
+
+		var oldLen, newLen int
+		for _, op := range ops[h[0]:h[1]] {
+			oldLen, newLen = advance(op, oldLen, newLen)
+		}
+
+		fmt.Fprintf(&buf, "@@ -%s +%s @@\n", hunkRange(oldLine, oldLen),
+			hunkRange(newLine, newLen))
+		for ; pos < h[1]; pos++ {
+			op := ops[pos]
+			buf.WriteByte(op.k)
+			buf.WriteString(op.s)
+			if len(op.s) == 0 || op.s[len(op.s)-1] != '\n' {
+				buf.WriteString("\n\\ No newline at end of file\n")
+			}
+			oldLine, newLine = advance(op, oldLine, newLine)
+		}
+	}
+
+	_, err := buf.WriteTo(w)
+	return err
+}
+
+// advance increments the given old and new line counters according to
+// the kind of the given line.
+func advance(op lineOp, old, new int) (int, int) {
+	switch op.k {
+	case ' ':
+		return old + 1, new + 1
+	case '-':
+		return old + 1, new
+	default:
+		return old, new + 1
+	}
+}
+
+// hunkRange formats the range of a hunk starting after the given line
+// and spanning the given amount of lines.
+func hunkRange(line, n int) string {
+	if n == 0 {
+		return fmt.Sprintf("%d,0", line)
+	}
+	return fmt.Sprintf("%d,%d", line+1, n)
+}
+
+// splitLines splits the given data into lines, each line retains its
+// trailing newline character.
+func splitLines(data []byte) []string {
+	var lines []string
+	for len(data) > 0 {
+		i := bytes.IndexByte(data, '\n') + 1
+		if i == 0 {
+			i = len(data)
+		}
+
+		lines = append(lines, string(data[:i]))
+		data = data[i:]
+	}
+
+	return lines
+}
+
+// diffLines computes a line based diff between a and b using the
+// longest common subsequence of both.
+func diffLines(a, b []string) []lineOp {
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	var ops []lineOp
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = append(ops, lineOp{' ', a[i]})
+			i, j = i+1, j+1
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, lineOp{'-', a[i]})
+			i++
+		default:
+			ops = append(ops, lineOp{'+', b[j]})
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		ops = append(ops, lineOp{'-', a[i]})
+	}
+	for ; j < len(b); j++ {
+		ops = append(ops, lineOp{'+', b[j]})
+	}
+
+	return ops
+}
@@ -0,0 +1,86 @@
+package abuildlint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"mvdan.cc/sh/syntax"
+	"os"
+	"strings"
+)
+
+// Diagnostic describes a single style violation found in an APKBUILD.
+type Diagnostic struct {
+	Check     string        `json:"check"`         // Identifier of the check
+	Name      string        `json:"name"`          // Name of the check
+	File      string        `json:"file"`          // Name of the APKBUILD
+	Line      uint          `json:"line"`          // Line number, zero if unknown
+	Column    uint          `json:"column"`        // Column number, zero if unknown
+	EndLine   uint          `json:"end_line"`      // Line number of the end, zero if unknown
+	EndColumn uint          `json:"end_column"`    // Column number after the end, zero if unknown
+	Severity  Severity      `json:"severity"`      // Severity of the violation
+	Message   string        `json:"message"`       // Formatted message
+	Format    string        `json:"format"`        // Unformatted message
+	Args      []interface{} `json:"args"`          // Arguments for the format
+	Fix       []Edit        `json:"fix,omitempty"` // Edits fixing the violation
+}
+
+// Formatter writes a violation to the given writer.
+type Formatter func(io.Writer, *Diagnostic) error
+
+// Map containing all supported output formats.
+var Formatters = map[string]Formatter{
+	"text": FormatText,
+	"json": FormatJSON,
+}
+
+// ParseDiagnostic returns a style violation describing the given error
+// encountered while reading or parsing the APKBUILD with the given file
+// name. The position is only included for syntax errors.
+func ParseDiagnostic(fn string, err error) *Diagnostic {
+	v := Diagnostic{
+		Check:    checkParse.ID,
+		Name:     checkParse.Name,
+		File:     fn,
+		Severity: checkParse.Severity,
+		Message:  err.Error(),
+		Format:   "%s",
+	}
+
+	var pos syntax.Pos
+	switch e := err.(type) {
+	case syntax.ParseError:
+		pos, v.Message = e.Pos, e.Text
+	case syntax.LangError:
+		pos = e.Pos
+		v.Message = strings.TrimPrefix(e.Error(), e.Filename+":"+pos.String()+": ")
+	case *os.PathError:
+		v.Message = e.Err.Error()
+	}
+
+	v.Line, v.Column = pos.Line(), pos.Col()
+	v.EndLine, v.EndColumn = v.Line, v.Column
+	v.Args = []interface{}{v.Message}
+	return &v
+}
+
+// FormatText writes the violation as a single human readable line of
+// the form name:line:column: severity: message [id name]. Line and
+// column are omitted if the violation doesn't refer to a specific
+// position.
+func FormatText(w io.Writer, v *Diagnostic) error {
+	prefix := v.File
+	if v.Line > 0 {
+		prefix += fmt.Sprintf(":%d:%d", v.Line, v.Column)
+	}
+
+	_, err := fmt.Fprintf(w, "%s: %s: %s [%s %s]\n", prefix, v.Severity,
+		v.Message, v.Check, v.Name)
+	return err
+}
+
+// FormatJSON writes the violation as a single JSON object followed by
+// a newline character.
+func FormatJSON(w io.Writer, v *Diagnostic) error {
+	return json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,164 @@
+//go:build ignore
+// +build ignore
+
+// gen_spdx generates spdx.go from the JSON files of the given version
+// of the SPDX License List Data repository. It is invoked by go
+// generate, see license.go. Usage:
+//
+//	go run gen_spdx.go [-d dir] [-o file] version
+//
+// By default the JSON files are downloaded from GitHub. If -d is
+// given, they are read from the json directory of a local checkout of
+// the repository instead.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"go/format"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// URL of a JSON file of a specific version of the SPDX License List.
+const dataURL = "https://raw.githubusercontent.com/spdx/license-list-data/v%s/json/%s"
+
+// licenseList represents either the licenses.json or the exceptions.json
+// file of the SPDX License List.
+type licenseList struct {
+	Version  string `json:"licenseListVersion"`
+	Licenses []struct {
+		ID         string `json:"licenseId"`
+		Deprecated bool   `json:"isDeprecatedLicenseId"`
+	} `json:"licenses"`
+	Exceptions []struct {
+		ID         string `json:"licenseExceptionId"`
+		Deprecated bool   `json:"isDeprecatedLicenseId"`
+	} `json:"exceptions"`
+}
+
+var (
+	dir    = flag.String("d", "", "read JSON files from the given directory")
+	output = flag.String("o", "spdx.go", "write generated code to the given file")
+)
+
+// open returns a reader for the JSON file with the given name.
+func open(version, name string) (io.ReadCloser, error) {
+	if *dir != "" {
+		return os.Open(filepath.Join(*dir, name))
+	}
+
+	resp, err := http.Get(fmt.Sprintf(dataURL, version, name))
+	if err != nil {
+		return nil, err
+	} else if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("%s: %s", name, resp.Status)
+	}
+
+	return resp.Body, nil
+}
+
+// load decodes the JSON file with the given name and checks that it
+// belongs to the given version of the SPDX License List.
+func load(version, name string) (*licenseList, error) {
+	r, err := open(version, name)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var list licenseList
+	if err := json.NewDecoder(r).Decode(&list); err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	if list.Version != version {
+		return nil, fmt.Errorf("%s: unexpected version %q", name, list.Version)
+	}
+
+	return &list, nil
+}
+
+// writeMap writes a map literal mapping the lowercased identifiers to
+// the given values sorted by key.
+func writeMap(w io.Writer, comment, name, typ string, m map[string]string) {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	fmt.Fprintf(w, "\n%svar %s = map[string]%s{\n", comment, name, typ)
+	for _, key := range keys {
+		fmt.Fprintf(w, "\t%q: %s,\n", key, m[key])
+	}
+	fmt.Fprintln(w, "}")
+}
+
+func generate(version string) ([]byte, error) {
+	licenses, err := load(version, "licenses.json")
+	if err != nil {
+		return nil, err
+	}
+	exceptions, err := load(version, "exceptions.json")
+	if err != nil {
+		return nil, err
+	}
+
+	lids := make(map[string]string)
+	eids := make(map[string]string)
+	deprecated := make(map[string]string)
+	add := func(id string, isDeprecated bool, m map[string]string) {
+		if isDeprecated {
+			deprecated[strings.ToLower(id)] = strconv.Quote(id)
+		} else {
+			m[strings.ToLower(id)] = "true"
+		}
+	}
+	for _, l := range licenses.Licenses {
+		add(l.ID, l.Deprecated, lids)
+	}
+	for _, e := range exceptions.Exceptions {
+		add(e.ID, e.Deprecated, eids)
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "// Code generated by gen_spdx.go from version %s of the SPDX\n", version)
+	fmt.Fprintln(&buf, "// License List. DO NOT EDIT.")
+	fmt.Fprintln(&buf, "\npackage abuildlint")
+	writeMap(&buf, "// Map containing all lowercased license identifiers of the SPDX\n"+
+		"// License List which are not deprecated.\n", "spdxLicenses", "bool", lids)
+	writeMap(&buf, "// Map containing all lowercased license exception identifiers of the\n"+
+		"// SPDX License List which are not deprecated.\n", "spdxExceptions", "bool", eids)
+	writeMap(&buf, "// Map containing all deprecated license and license exception\n"+
+		"// identifiers of the SPDX License List, indexed by their lowercased\n"+
+		"// form.\n", "spdxDeprecated", "string", deprecated)
+
+	return format.Source(buf.Bytes())
+}
+
+func main() {
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintf(os.Stderr, "Usage: %s [-d dir] [-o file] version\n", os.Args[0])
+		os.Exit(2)
+	}
+
+	src, err := generate(strings.TrimPrefix(flag.Arg(0), "v"))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,217 @@
+package abuildlint
+
+//go:generate go run gen_spdx.go -o spdx.go 3.25.0
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Map containing deprecated SPDX license identifiers and legacy license
+// names commonly found in APKBUILDs, mapped to the SPDX license
+// expression which should be used instead.
+var legacyLicenses = map[string]string{
+	"GPL-1.0":                          "GPL-1.0-only",
+	"GPL-1.0+":                         "GPL-1.0-or-later",
+	"GPL-2.0":                          "GPL-2.0-only",
+	"GPL-2.0+":                         "GPL-2.0-or-later",
+	"GPL-3.0":                          "GPL-3.0-only",
+	"GPL-3.0+":                         "GPL-3.0-or-later",
+	"LGPL-2.0":                         "LGPL-2.0-only",
+	"LGPL-2.0+":                        "LGPL-2.0-or-later",
+	"LGPL-2.1":                         "LGPL-2.1-only",
+	"LGPL-2.1+":                        "LGPL-2.1-or-later",
+	"LGPL-3.0":                         "LGPL-3.0-only",
+	"LGPL-3.0+":                        "LGPL-3.0-or-later",
+	"AGPL-1.0":                         "AGPL-1.0-only",
+	"AGPL-3.0":                         "AGPL-3.0-only",
+	"GFDL-1.1":                         "GFDL-1.1-only",
+	"GFDL-1.2":                         "GFDL-1.2-only",
+	"GFDL-1.3":                         "GFDL-1.3-only",
+	"GPL2":                             "GPL-2.0-only",
+	"GPL2+":                            "GPL-2.0-or-later",
+	"GPL-2":                            "GPL-2.0-only",
+	"GPL-2+":                           "GPL-2.0-or-later",
+	"GPL3":                             "GPL-3.0-only",
+	"GPL3+":                            "GPL-3.0-or-later",
+	"GPL-3":                            "GPL-3.0-only",
+	"GPL-3+":                           "GPL-3.0-or-later",
+	"LGPL2":                            "LGPL-2.0-only",
+	"LGPL2+":                           "LGPL-2.0-or-later",
+	"LGPL2.1":                          "LGPL-2.1-only",
+	"LGPL2.1+":                         "LGPL-2.1-or-later",
+	"LGPL3":                            "LGPL-3.0-only",
+	"LGPL3+":                           "LGPL-3.0-or-later",
+	"AGPL3":                            "AGPL-3.0-only",
+	"AGPL3+":                           "AGPL-3.0-or-later",
+	"APACHE":                           "Apache-2.0",
+	"APACHE-2":                         "Apache-2.0",
+	"ASL-2.0":                          "Apache-2.0",
+	"Apache2":                          "Apache-2.0",
+	"MPL2":                             "MPL-2.0",
+	"PSF":                              "PSF-2.0",
+	"Artistic":                         "Artistic-1.0-Perl",
+	"PerlArtistic":                     "Artistic-1.0-Perl",
+	"BSD-2":                            "BSD-2-Clause",
+	"BSD-3":                            "BSD-3-Clause",
+	"BSD-4":                            "BSD-4-Clause",
+	"wxWindows":                        "LGPL-2.0-or-later WITH WxWindows-exception-3.1",
+	"eCos-2.0":                         "GPL-2.0-or-later WITH eCos-exception-2.0",
+	"StandardML-NJ":                    "SMLNJ",
+	"Nunit":                            "zlib-acknowledgement",
+	"GPL-2.0-with-autoconf-exception":  "GPL-2.0-only WITH Autoconf-exception-2.0",
+	"GPL-2.0-with-bison-exception":     "GPL-2.0-only WITH Bison-exception-2.2",
+	"GPL-2.0-with-classpath-exception": "GPL-2.0-only WITH Classpath-exception-2.0",
+	"GPL-2.0-with-font-exception":      "GPL-2.0-only WITH Font-exception-2.0",
+	"GPL-2.0-with-GCC-exception":       "GPL-2.0-only WITH GCC-exception-2.0",
+	"GPL-3.0-with-autoconf-exception":  "GPL-3.0-only WITH Autoconf-exception-3.0",
+	"GPL-3.0-with-GCC-exception":       "GPL-3.0-only WITH GCC-exception-3.1",
+	"bzip2-1.0.5":                      "bzip2-1.0.6",
+	"BSD-2-Clause-FreeBSD":             "BSD-2-Clause",
+	"BSD-2-Clause-NetBSD":              "BSD-2-Clause",
+}
+
+// licenseParser is a recursive descent parser for SPDX license
+// expressions as defined in appendix IV of the SPDX specification.
+type licenseParser struct {
+	t []string // Tokens of the expression
+	n int      // Index of the current token
+
+	licenses   []string // License identifiers found so far
+	exceptions []string // Exception identifiers found so far
+}
+
+// ParseLicense parses the given SPDX license expression and returns
+// all license identifiers and license exception identifiers referenced
+// in it. The operators AND, OR and WITH as well as parentheses are
+// supported.
+func ParseLicense(expr string) ([]string, []string, error) {
+	p := licenseParser{t: tokenizeLicense(expr)}
+	if len(p.t) == 0 {
+		return nil, nil, errors.New("empty expression")
+	}
+
+	if err := p.parseOr(); err != nil {
+		return nil, nil, err
+	}
+	if p.n < len(p.t) {
+		return nil, nil, fmt.Errorf("unexpected %q", p.t[p.n])
+	}
+
+	return p.licenses, p.exceptions, nil
+}
+
+// IsLicense reports whether the given string is a known SPDX license
+// identifier, optionally followed by a '+', or a user defined license
+// reference. Like all SPDX identifiers, license identifiers are
+// matched case-insensitively.
+func IsLicense(id string) bool {
+	if strings.HasPrefix(id, "LicenseRef-") {
+		return len(id) > len("LicenseRef-")
+	}
+
+	return spdxLicenses[strings.ToLower(strings.TrimSuffix(id, "+"))]
+}
+
+// isException reports whether the given string is a known SPDX license
+// exception identifier.
+func isException(id string) bool {
+	return spdxExceptions[strings.ToLower(id)]
+}
+
+// deprecatedID returns the canonical form of the given deprecated SPDX
+// license or license exception identifier. It reports whether the
+// identifier is deprecated.
+func deprecatedID(id string) (string, bool) {
+	canonical, ok := spdxDeprecated[strings.ToLower(id)]
+	return canonical, ok
+}
+
+// tokenizeLicense splits an SPDX license expression into tokens.
+func tokenizeLicense(expr string) []string {
+	expr = strings.NewReplacer("(", " ( ", ")", " ) ").Replace(expr)
+	return strings.Fields(expr)
+}
+
+// next returns the current token without consuming it or an empty
+// string if all tokens have been consumed.
+func (p *licenseParser) next() string {
+	if p.n >= len(p.t) {
+		return ""
+	}
+	return p.t[p.n]
+}
+
+// parseOr parses an expression of the form and-expr {OR and-expr}.
+func (p *licenseParser) parseOr() error {
+	if err := p.parseAnd(); err != nil {
+		return err
+	}
+
+	for p.next() == "OR" {
+		p.n++
+		if err := p.parseAnd(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseAnd parses an expression of the form with-expr {AND with-expr}.
+func (p *licenseParser) parseAnd() error {
+	if err := p.parseWith(); err != nil {
+		return err
+	}
+
+	for p.next() == "AND" {
+		p.n++
+		if err := p.parseWith(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseWith parses an expression of the form simple-expr [WITH
+// exception] or a parenthesized expression.
+func (p *licenseParser) parseWith() error {
+	tok := p.next()
+	switch tok {
+	case "":
+		return errors.New("unexpected end of expression")
+	case "(":
+		p.n++
+		if err := p.parseOr(); err != nil {
+			return err
+		}
+		if p.next() != ")" {
+			return errors.New("missing closing parenthesis")
+		}
+		p.n++
+		return nil
+	case ")", "AND", "OR", "WITH":
+		return fmt.Errorf("unexpected %q", tok)
+	}
+
+	p.n++
+	p.licenses = append(p.licenses, tok)
+	if p.next() != "WITH" {
+		return nil
+	}
+	p.n++
+
+	exception := p.next()
+	switch exception {
+	case "":
+		return errors.New("unexpected end of expression")
+	case "(", ")", "AND", "OR", "WITH":
+		return fmt.Errorf("unexpected %q", exception)
+	}
+
+	p.n++
+	p.exceptions = append(p.exceptions, exception)
+	return nil
+}
@@ -0,0 +1,1378 @@
+// Package abuildlint implements a linter checking Alpine Linux APKBUILDs
+// for style mistakes. It is used by the abuild-lint utility but can also
+// be used directly by other Go programs. Additional checks can be added
+// by other packages using Register.
+package abuildlint
+
+import (
+	"bytes"
+	"fmt"
+	"mvdan.cc/sh/syntax"
+	"net/mail"
+	"net/url"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// Prefix used to indicate that a comment specifies the package
+	// maintainer.
+	maintainerPrefix = " Maintainer:"
+
+	// Prefix used to indicate that a comment specifies a package
+	// contributor.
+	contributorPrefix = " Contributor:"
+)
+
+// metaPos describes the position of a metadata variable in an APKBUILD.
+type metaPos int
+
+const (
+	// Metadata variable must be declared before the first function
+	// declaration.
+	beforeFuncs metaPos = iota
+
+	// Metadata variable must be declared after the last function
+	// declaration.
+	afterFuncs
+)
+
+// metadata represents an APKBUILD metadata variable.
+type metadata struct {
+	p metaPos // Position of the metadata variable
+	r bool    // Whether the metadata variable is required
+}
+
+// Array containing all variables which are directly used by
+// abuild and should thus be declared globally.
+var metadataVariables = map[string]metadata{
+	"pkgname":           {beforeFuncs, true},
+	"pkgver":            {beforeFuncs, true},
+	"pkgrel":            {beforeFuncs, true},
+	"pkgdesc":           {beforeFuncs, true},
+	"url":               {beforeFuncs, true},
+	"arch":              {beforeFuncs, true},
+	"license":           {beforeFuncs, true},
+	"depends":           {beforeFuncs, false},
+	"depends_doc":       {beforeFuncs, false},
+	"depends_dev":       {beforeFuncs, false},
+	"makedepends":       {beforeFuncs, false},
+	"makedepends_build": {beforeFuncs, false},
+	"makedepends_host":  {beforeFuncs, false},
+	"checkdepends":      {beforeFuncs, false},
+	"install":           {beforeFuncs, false},
+	"triggers":          {beforeFuncs, false},
+	"subpackages":       {beforeFuncs, false},
+	"source":            {beforeFuncs, false},
+	"disturl":           {beforeFuncs, false},
+	"svnurl":            {beforeFuncs, false},
+	"giturl":            {beforeFuncs, false},
+	"options":           {beforeFuncs, false},
+	"sonameprefix":      {beforeFuncs, false},
+	"somask":            {beforeFuncs, false},
+	"replaces":          {beforeFuncs, false},
+	"conflicts":         {beforeFuncs, false},
+	"provides":          {beforeFuncs, false},
+	"provider_priority": {beforeFuncs, false},
+	"install_if":        {beforeFuncs, false},
+	"pkgusers":          {beforeFuncs, false},
+	"pkggroups":         {beforeFuncs, false},
+	"langdir":           {beforeFuncs, false},
+	"builddir":          {beforeFuncs, false},
+	"ldpath":            {beforeFuncs, false},
+	"patch_args":        {beforeFuncs, false},
+	"md5sums":           {afterFuncs, false},
+	"sha256sums":        {afterFuncs, false},
+	"sha512sums":        {afterFuncs, false},
+}
+
+// Array containing all functions which can be declared by an APKBUILD
+// and are then called from abuild(1). The elements of the array should
+// be sorted by invocation time.
+var packageFunctions = []string{
+	"sanitycheck",
+	"snapshot",
+	"fetch",
+	"unpack",
+	"prepare",
+	"build",
+	"check",
+	"package",
+}
+
+// Array containing all split functions for which abuild(1) provides a
+// default implementation.
+var defaultSplitFunctions = []string{
+	"dbg",
+	"dev",
+	"doc",
+	"lang",
+	"libs",
+	"openrc",
+	"static",
+	"bashcomp",
+	"zshcomp",
+	"fishcomp",
+	"pyc",
+}
+
+// Array containing all architectures supported by abuild(1). Besides
+// the actual architectures it also contains the special values all and
+// noarch.
+var architectures = []string{
+	"all",
+	"noarch",
+	"x86_64",
+	"x86",
+	"aarch64",
+	"armhf",
+	"armv7",
+	"ppc64le",
+	"s390x",
+	"riscv64",
+}
+
+// Maximum length of package descriptions accepted by abuild(1).
+const maxPkgdescLen = 128
+
+// Array containing all articles a package description shouldn't start
+// with.
+var articles = []string{"a", "an", "the"}
+
+// Array containing all dependency metadata variables.
+var dependVariables = []string{
+	"depends",
+	"makedepends",
+	"makedepends_build",
+	"makedepends_host",
+	"checkdepends",
+}
+
+// Array containing all version comparison operators supported in
+// dependencies.
+var dependOperators = []string{"<", "<=", "=", ">=", ">", "~", "~=", "=~"}
+
+// Array containing all options supported in the options metadata
+// variable.
+var options = []string{
+	"!archcheck",
+	"!check",
+	"!dbg",
+	"!fhs",
+	"!spdx",
+	"!strip",
+	"!tracedeps",
+	"charset.alias",
+	"checkroot",
+	"chmod-clean",
+	"keepdirs",
+	"ldpath-recursive",
+	"lib64",
+	"libtool",
+	"net",
+	"setcap",
+	"sover-namecheck",
+	"suid",
+	"textrels",
+	"toolchain",
+}
+
+// Minimum length of package versions considered when searching for
+// hard-coded package versions in URLs. Shorter versions, e.g. 1, are
+// likely to cause false positives.
+const minVersionLen = 3
+
+// checksum describes a checksum metadata variable.
+type checksum struct {
+	v string // Name of the metadata variable
+	n int    // Length of the hex encoded digests
+	d string // Replacement variable if the variable is deprecated
+}
+
+// Array containing all checksum metadata variables supported by
+// abuild(1), sorted by preference.
+var checksumVariables = []checksum{
+	{"sha512sums", 128, ""},
+	{"sha256sums", 64, "sha512sums"},
+	{"md5sums", 32, "sha512sums"},
+}
+
+// addressComment represents a comment which prefixed with a certain
+// string and contains an RFC 5322 address.
+type addressComment struct {
+	c syntax.Comment // Comment containing the address
+	a *mail.Address  // RFC 5322 address contained in the comment
+}
+
+// Linter lints Alpine Linux APKBUILDs.
+type Linter struct {
+	f *APKBUILD // APKBUILD which should be checked
+	c Check     // Check which is currently performed
+
+	cfg *Config // Configuration of the linter, may be nil
+
+	e map[string]bool // Identifiers of checks to perform, all if nil
+
+	x     bool   // Whether fixable violations should be fixed
+	edits []Edit // Edits fixing the fixable violations
+
+	found []*Diagnostic // Style violations found so far
+}
+
+// Options configures a linter.
+type Options struct {
+	// Configuration of the linter, may be nil.
+	Config *Config
+
+	// Identifiers of checks which should be performed, all if nil.
+	Enabled map[string]bool
+
+	// Whether fixable violations should be fixed instead of being
+	// reported, see Linter.Fix.
+	Fix bool
+}
+
+// NewLinter returns a linter for the given APKBUILD.
+func NewLinter(f *APKBUILD, opts Options) *Linter {
+	return &Linter{f: f, cfg: opts.Config, e: opts.Enabled, x: opts.Fix}
+}
+
+// Lint performs all enabled registered checks and returns the style
+// violations found by them sorted by position, see lint.
+func (l *Linter) Lint() []*Diagnostic {
+	l.found, l.edits = nil, nil
+
+	var enabled []Check
+	for _, c := range registry {
+		if l.e == nil || l.e[c.Info().ID] {
+			enabled = append(enabled, c)
+		}
+	}
+
+	return l.lint(enabled...)
+}
+
+// lint performs the given checks and returns all style violations found
+// so far sorted by line, column and check identifier. Violations with
+// the same position found by the same check are returned in the order
+// they were found.
+func (l *Linter) lint(checks ...Check) []*Diagnostic {
+	for _, c := range checks {
+		l.run(c)
+	}
+
+	sort.SliceStable(l.found, func(i, j int) bool {
+		a, b := l.found[i], l.found[j]
+		switch {
+		case a.Line != b.Line:
+			return a.Line < b.Line
+		case a.Column != b.Column:
+			return a.Column < b.Column
+		default:
+			return a.Check < b.Check
+		}
+	})
+
+	return l.found
+}
+
+// Fix returns the source code of the APKBUILD with all fixable style
+// violations found by Lint fixed. It also reports whether any fixes
+// were applied.
+func (l *Linter) Fix() ([]byte, bool) {
+	return ApplyEdits(l.f.src, l.edits), len(l.edits) > 0
+}
+
+// run performs the given check. Violations found by the check are
+// recorded with the identifier of the check.
+func (l *Linter) run(c Check) {
+	l.c = c
+	c.Run(l)
+	l.c = nil
+}
+
+// APKBUILD returns the APKBUILD checked by the linter.
+func (l *Linter) APKBUILD() *APKBUILD {
+	return l.f
+}
+
+// Config returns the configuration of the linter, it may be nil.
+func (l *Linter) Config() *Config {
+	return l.cfg
+}
+
+// Reportf records a style violation found by the check currently
+// performed at the position of the given node according to format. If
+// the node is nil, the violation doesn't refer to a specific position.
+// It must only be called from Check.Run.
+func (l *Linter) Reportf(node syntax.Node, format string,
+	argv ...interface{}) {
+	l.errorf(node, format, argv...)
+}
+
+// ReportFixf is like Reportf but additionally supplies edits which fix
+// the style violation, see Fix.
+func (l *Linter) ReportFixf(node syntax.Node, fix []Edit, format string,
+	argv ...interface{}) {
+	l.fixablef(node, fix, format, argv...)
+}
+
+// lintComments checks that all comments start with a space. Shebangs
+// are no exception to this rule since they shouldn't appear in an
+// APKBUILD at all.
+func (l *Linter) lintComments() {
+	l.f.Walk(func(node syntax.Node) bool {
+		c, ok := node.(*syntax.Comment)
+		if ok && c.Text != "" && !strings.HasPrefix(c.Text, " ") {
+			off := c.Pos().Offset() + 1 // skip '#'
+			l.fixable(node, []Edit{{off, off, " "}},
+				badCommentPrefix)
+		}
+
+		return true
+	})
+}
+
+// lintMaintainerAndContributors checks the APKBUILD maintainer and
+// contributor comments. It complains if there is not exactly one
+// maintainer comment, if the address specified in a maintainer or
+// contributors comment doesn't conform to RFC 5322.
+//
+// Besides it checks that contributor comments are declared before
+// maintainer comments and that contributor comments aren't declared
+// twice. Regarding the order of the comments it also checks that the
+// maintainer comment is declared before the first variable assignment.
+func (l *Linter) lintMaintainerAndContributors() {
+	var maintainer *addressComment
+	n, m := l.lintAddressComments(maintainerPrefix)
+	if n == 0 {
+		l.error(nil, missingMaintainer)
+	} else if n > 1 {
+		l.error(&m[len(m)-1].c, tooManyMaintainers)
+	}
+
+	if len(m) >= 1 {
+		maintainer = &m[0]
+	}
+
+	if maintainer != nil && len(l.f.Assignments) > 0 &&
+		maintainer.c.Pos().After(l.f.Assignments[0].Pos()) {
+		l.error(&maintainer.c, maintainerAfterAssign)
+	}
+
+	addrMap := make(map[string]bool)
+	_, contributors := l.lintAddressComments(contributorPrefix)
+	for _, c := range contributors {
+		if maintainer != nil && c.c.Pos().After(maintainer.c.Pos()) {
+			l.error(&c.c, wrongAddrCommentOrder)
+		}
+
+		_, ok := addrMap[c.a.String()]
+		if ok {
+			l.error(&c.c, repeatedAddrComment)
+		} else {
+			addrMap[c.a.String()] = true
+		}
+	}
+
+	// TODO: check for same address in contributor and maintainer?
+}
+
+// lintGlobalVariables checks that all declared globally declared
+// variables which are not prefixed with an underscore are metadata
+// variables actually picked up by abuild(1).
+func (l *Linter) lintGlobalVariables() {
+	for _, a := range l.f.Assignments {
+		v := a.Name.Value
+		if !l.isMetaVar(v) && !isPrefixVar(v) {
+			l.errorf(&a, invalidGlobalVar, v)
+			continue
+		}
+	}
+}
+
+// lintUnusedVariables checks if all globally and locally declared
+// non-metadata variable are actually used somewhere in the APKBUILD.
+func (l *Linter) lintUnusedVariables() {
+	l.f.Walk(func(node syntax.Node) bool {
+		switch x := node.(type) {
+		case *syntax.CallExpr:
+			if len(x.Args) > 0 {
+				return false // FOO=bar ./foo
+			}
+		case *syntax.Assign:
+			v := x.Name.Value
+			if !l.isMetaVar(v) && l.f.IsUnusedVar(v) {
+				l.errorf(x, variableUnused, v)
+			}
+		}
+
+		return true
+	})
+}
+
+// lintGlobalCmdSubsts check that all global shell statements don't use
+// any kind of command substitutions.
+func (l *Linter) lintGlobalCmdSubsts() {
+	l.f.Walk(func(node syntax.Node) bool {
+		switch node.(type) {
+		case *syntax.CmdSubst:
+			l.error(node, cmdSubstInGlobalVar)
+		case *syntax.FuncDecl:
+			return false
+		}
+
+		return true
+	})
+}
+
+// lintLocalVariables checks that all variables declared inside a
+// function are declared using the local keyword.
+func (l *Linter) lintLocalVariables() {
+	vars := make(map[string][]string)
+	for n, f := range l.f.Functions {
+		fn := func(node syntax.Node) bool {
+			switch x := node.(type) {
+			case *syntax.CallExpr:
+				if len(x.Args) > 0 {
+					return false // FOO=bar ./foo
+				}
+			case *syntax.DeclClause:
+				variant := x.Variant.Value
+				if variant != "local" && variant != "export" {
+					return true
+				}
+
+				for _, a := range x.Assigns {
+					vars[n] = append(vars[n], a.Name.Value)
+				}
+			case *syntax.Assign:
+				if !l.isValidVarScope(vars[n], x.Name) {
+					l.errorf(x, nonLocalVariable, x.Name.Value)
+				}
+			case *syntax.WordIter:
+				if !l.isValidVarScope(vars[n], x.Name) {
+					l.errorf(x, nonLocalVariable, x.Name.Value)
+				}
+			}
+
+			return true
+		}
+
+		syntax.Walk(&f, fn)
+	}
+}
+
+// lintParamExpression checks for long parameter expansion of the form
+// ${…} and checks if they can be replaced by a semantically equivalent
+// short parameter expansion of the $… form.
+func (l *Linter) lintParamExpression() {
+	var words []*syntax.Word
+	l.f.Walk(func(node syntax.Node) bool {
+		word, ok := node.(*syntax.Word)
+		if ok {
+			words = append(words, word)
+			return false
+		}
+
+		return true
+	})
+
+	for _, word := range words {
+		nparts := len(word.Parts)
+		for n, p := range word.Parts {
+			paramExp, ok := p.(*syntax.ParamExp)
+			if !ok {
+				continue
+			} else if paramExp.Short {
+				continue
+			} else if isParamExp(paramExp) {
+				continue
+			}
+
+			// Replacing the expansion is only safe if the following
+			// literal doesn't start with a character which would be
+			// considered part of the parameter name.
+			if n < nparts-1 {
+				next := word.Parts[n+1]
+				lit, ok := next.(*syntax.Lit)
+				if !ok || (lit.Value != "" && isNamePart(lit.Value[:1])) {
+					continue
+				}
+			}
+
+			fix := Edit{paramExp.Pos().Offset(), paramExp.End().Offset(),
+				"$" + paramExp.Param.Value}
+			l.fixablef(paramExp, []Edit{fix}, trivialLongParamExp,
+				paramExp.Param.Value, paramExp.Param.Value)
+		}
+	}
+
+}
+
+// lintMetadataPlacement checks the placement of metadata variables.
+// Some need to be declared before the first function declaration,
+// others need to be declared after the last function declaration.
+func (l *Linter) lintMetadataPlacement() {
+	var firstFn, lastFn *syntax.FuncDecl
+	for _, fn := range l.f.Functions {
+		if firstFn == nil || !fn.Pos().After(firstFn.Pos()) {
+			firstFn = &fn
+		}
+		if lastFn == nil || fn.Pos().After(lastFn.Pos()) {
+			lastFn = &fn
+		}
+	}
+
+	for _, v := range l.f.Assignments {
+		name := v.Name.Value
+		mpos, ok := l.metadata(name)
+		if !ok {
+			continue
+		}
+
+		vpos := v.Pos()
+		switch mpos.p {
+		case beforeFuncs:
+			if firstFn != nil && vpos.After(firstFn.Pos()) {
+				l.errorf(&v, metadataBeforeFunc, name)
+			}
+		case afterFuncs:
+			if lastFn != nil && !vpos.After(lastFn.Pos()) {
+				l.fixablef(&v, l.moveToEnd(vpos), metadataAfterFunc, name)
+			}
+		}
+	}
+}
+
+// lintRequiredMetadata checks that all required metadata variables are
+// defined in the APKBUILD. Missing variables are reported in
+// alphabetical order.
+func (l *Linter) lintRequiredMetadata() {
+	var required []string
+	for n, m := range metadataVariables {
+		if m.r {
+			required = append(required, n)
+		}
+	}
+	sort.Strings(required)
+
+	for _, n := range required {
+		if !l.f.IsGlobalVar(n) {
+			l.errorf(nil, missingMetadata, n)
+		}
+	}
+}
+
+// lintFunctionOrder checks that all package functions are declared in
+// the order they are called by abuild(1).
+func (l *Linter) lintFunctionOrder() {
+	var seen []*syntax.FuncDecl
+	for _, fn := range l.packageFunctions() {
+		decl, ok := l.f.Functions[fn]
+		if !ok {
+			continue
+		}
+
+		for _, s := range seen {
+			if !decl.Pos().After(s.Pos()) {
+				l.errorf(&decl, wrongFuncOrder,
+					decl.Name.Value, s.Name.Value)
+			}
+		}
+		seen = append(seen, &decl)
+	}
+
+	pkg, ok := l.f.Functions["package"]
+	if !ok {
+		return
+	}
+
+	for _, fn := range l.splitFunctions() {
+		decl := l.f.Functions[fn]
+		if !decl.Pos().After(pkg.Pos()) {
+			l.errorf(&decl, wrongFuncOrder, fn, "package")
+		}
+	}
+}
+
+// lintSubpackages checks that the split functions of all subpackages
+// are declared unless abuild(1) provides a default implementation and
+// that all declared functions which are not prefixed with an
+// underscore are either metadata functions or split functions.
+func (l *Linter) lintSubpackages() {
+	for _, subpkg := range l.f.Subpackages() {
+		_, ok := l.f.Functions[subpkg.Split]
+		if !ok && !isIncluded(defaultSplitFunctions, subpkg.Split) {
+			l.errorf(l.subpackageAssign(subpkg.Split), missingSplitFunc,
+				subpkg.Split, subpkg.Name)
+		}
+	}
+
+	used := l.splitFunctions()
+	for name, decl := range l.f.Functions {
+		if isPrefixVar(name) || isIncluded(used, name) ||
+			isIncluded(l.packageFunctions(), name) {
+			continue
+		}
+
+		l.errorf(&decl, unusedSplitFunc, name)
+	}
+}
+
+// lintBashisms checks for bash language features that are not allowed
+// to be used in an APKBUILD.
+func (l *Linter) lintBashisms() {
+	l.f.Walk(func(n syntax.Node) bool {
+		switch x := n.(type) {
+		case *syntax.TestClause:
+			l.errorf(x, forbiddenBashism, "test clause")
+		case *syntax.ExtGlob:
+			l.errorf(x, forbiddenBashism, "extended globbing expression")
+		case *syntax.ProcSubst:
+			l.errorf(x, forbiddenBashism, "process substitution")
+		case *syntax.LetClause:
+			l.errorf(x, forbiddenBashism, "let clause")
+		case *syntax.DeclClause:
+			v := x.Variant.Value
+			if v != "local" && v != "export" {
+				l.errorf(x.Variant, forbiddenBashism, v)
+			}
+		case *syntax.ParamExp:
+			if x.Excl || x.Length || x.Width || x.Index != nil {
+				l.errorf(x, forbiddenBashism, "advanced parameter expression")
+			}
+		case *syntax.ForClause:
+			if x.Select {
+				l.errorf(x, forbiddenBashism, "select clause")
+			}
+		case *syntax.FuncDecl:
+			if x.RsrvWord {
+				l.errorf(x, forbiddenBashism, "non-POSIX function declaration")
+			}
+		}
+
+		return true
+	})
+}
+
+// lintChecksums checks that the entries of all checksum metadata
+// variables correspond to the entries of the source metadata variable.
+// It complains about sources without a checksum, checksums without a
+// source, checksums declared in a different order than the sources and
+// malformed checksums. Parameter expansions in source file names match
+// any non-empty string.
+func (l *Linter) lintChecksums() {
+	sources := l.f.Sources()
+	patterns := make([]*regexp.Regexp, len(sources))
+	for n, src := range sources {
+		patterns[n] = filenamePattern(src.Filename)
+	}
+
+	for _, c := range checksumVariables {
+		assign := l.f.lastAssign(c.v)
+		if assign == nil {
+			continue
+		}
+
+		matched := make([]bool, len(sources))
+		prev := -1
+		for _, sum := range l.f.Checksums(c.v) {
+			if sum.Filename == "" {
+				l.errorf(assign, malformedChecksum, sum.Entry)
+				continue
+			}
+
+			if !isHexDigest(sum.Digest, c.n) {
+				l.errorf(assign, invalidDigest, sum.Filename, c.n)
+			}
+
+			idx := -1
+			for n, p := range patterns {
+				if !matched[n] && p.MatchString(sum.Filename) {
+					idx = n
+					break
+				}
+			}
+
+			if idx == -1 {
+				l.errorf(assign, unknownChecksum, sum.Filename)
+				continue
+			}
+			matched[idx] = true
+
+			if idx < prev {
+				l.errorf(assign, wrongChecksumOrder, sources[idx].Filename,
+					sources[prev].Filename)
+			} else {
+				prev = idx
+			}
+		}
+
+		for n, src := range sources {
+			if !matched[n] {
+				l.errorf(assign, missingChecksum, src.Entry, c.v)
+			}
+		}
+	}
+}
+
+// lintDeprecatedChecksums checks that no deprecated checksum metadata
+// variables are used and that only a single checksum metadata variable
+// is defined.
+func (l *Linter) lintDeprecatedChecksums() {
+	var first string
+	for _, a := range l.f.Assignments {
+		name := a.Name.Value
+		c, ok := findChecksum(name)
+		if !ok {
+			continue
+		}
+
+		if c.d != "" {
+			l.errorf(&a, deprecatedChecksum, name, c.d)
+		}
+
+		if first == "" {
+			first = name
+		} else if first != name {
+			l.errorf(&a, multipleChecksums, name, first)
+		}
+	}
+}
+
+// lintPackageFields checks that the values of the pkgname, pkgver and
+// pkgrel metadata variables are well-formed. Values which can't be
+// determined statically are not checked.
+func (l *Linter) lintPackageFields() {
+	fields := []struct {
+		v string
+		f func(string) bool
+		m string
+	}{
+		{"pkgname", isPkgname, invalidPkgname},
+		{"pkgver", isPkgver, invalidPkgver},
+		{"pkgrel", isPkgrel, invalidPkgrel},
+	}
+
+	for _, field := range fields {
+		assign := l.f.lastAssign(field.v)
+		value, ok := l.f.Value(field.v)
+		if assign == nil || !ok {
+			continue
+		}
+
+		if !field.f(value) {
+			l.errorf(assign, field.m, value)
+		}
+	}
+}
+
+// lintLicense checks that the value of the license metadata variable is
+// a valid SPDX license expression which only references known SPDX
+// license and exception identifiers. User defined license references
+// prefixed with LicenseRef- are permitted.
+func (l *Linter) lintLicense() {
+	assign := l.f.lastAssign("license")
+	value, ok := l.f.Value("license")
+	if assign == nil || !ok {
+		return
+	}
+
+	licenses, exceptions, err := ParseLicense(value)
+	if err != nil {
+		l.errorf(assign, invalidLicenseExpr, value, err.Error())
+		return
+	}
+
+	for _, license := range licenses {
+		if IsLicense(license) {
+			continue
+		}
+
+		id, deprecated := deprecatedID(license)
+		if repl, ok := legacyLicenses[license]; ok {
+			l.errorf(assign, legacyLicense, license, repl)
+		} else if repl, ok := legacyLicenses[id]; ok && deprecated {
+			l.errorf(assign, legacyLicense, license, repl)
+		} else if deprecated {
+			l.errorf(assign, deprecatedLicense, license)
+		} else {
+			l.errorf(assign, unknownLicense, license)
+		}
+	}
+
+	for _, exception := range exceptions {
+		if isException(exception) {
+			continue
+		}
+
+		if _, deprecated := deprecatedID(exception); deprecated {
+			l.errorf(assign, deprecatedException, exception)
+		} else {
+			l.errorf(assign, unknownException, exception)
+		}
+	}
+}
+
+// lintArch checks that the value of the arch metadata variable only
+// consists of known, possibly negated, architectures which are listed
+// only once and that noarch isn't combined with other architectures.
+// Values which can't be determined statically are not checked.
+func (l *Linter) lintArch() {
+	assign := l.f.lastAssign("arch")
+	value, ok := l.f.Value("arch")
+	if assign == nil || !ok {
+		return
+	}
+
+	var seen []string
+	fields := strings.Fields(value)
+	for _, field := range fields {
+		arch := strings.TrimPrefix(field, "!")
+		if !isIncluded(l.architectures(), arch) {
+			l.errorf(assign, unknownArch, arch)
+		} else if arch != field && (arch == "all" || arch == "noarch") {
+			l.errorf(assign, negatedArch, arch)
+		}
+
+		if isIncluded(seen, arch) {
+			l.errorf(assign, duplicateArch, arch)
+		} else {
+			seen = append(seen, arch)
+		}
+	}
+
+	if isIncluded(fields, "noarch") && len(seen) > 1 {
+		l.error(assign, noarchCombined)
+	}
+}
+
+// lintPkgdesc checks that the value of the pkgdesc metadata variable
+// doesn't exceed the maximum length, doesn't end with a period, doesn't
+// start with the package name or an article and has no leading or
+// trailing whitespace. Values which can't be determined statically are
+// not checked.
+func (l *Linter) lintPkgdesc() {
+	assign := l.f.lastAssign("pkgdesc")
+	value, ok := l.f.Value("pkgdesc")
+	if assign == nil || !ok {
+		return
+	}
+
+	if utf8.RuneCountInString(value) > maxPkgdescLen {
+		l.errorf(assign, pkgdescTooLong, maxPkgdescLen)
+	}
+	if strings.TrimSpace(value) != value {
+		l.error(assign, pkgdescSpace)
+	}
+	if strings.HasSuffix(value, ".") && !strings.HasSuffix(value, "...") {
+		l.error(assign, pkgdescPeriod)
+	}
+
+	fields := strings.Fields(value)
+	if len(fields) == 0 {
+		return
+	}
+	first := strings.ToLower(fields[0])
+
+	pkgname, ok := l.f.Value("pkgname")
+	if ok && first == strings.ToLower(pkgname) {
+		l.error(assign, pkgdescPkgname)
+	} else if isIncluded(articles, first) {
+		l.error(assign, pkgdescArticle)
+	}
+}
+
+// lintURLs checks that the url metadata variable and all remote entries
+// of the source metadata variable are well-formed URLs which use https
+// instead of http. Additionally, it complains about URLs containing the
+// package version instead of referring to the pkgver metadata variable.
+func (l *Linter) lintURLs() {
+	if value, ok := l.f.Value("url"); ok && value != "" {
+		l.lintURL(l.f.lastAssign("url"), value)
+	}
+
+	if assign := l.f.lastAssign("source"); assign != nil {
+		for _, src := range l.f.Sources() {
+			if !strings.Contains(src.URL, "$") {
+				l.lintURL(assign, src.URL)
+			}
+		}
+	}
+
+	pkgver, ok := l.f.Value("pkgver")
+	if !ok || len(pkgver) < minVersionLen {
+		return
+	}
+
+	for _, varname := range []string{"url", "source"} {
+		assign := l.f.lastAssign(varname)
+		if assign == nil || assign.Value == nil {
+			continue
+		}
+
+		for _, field := range strings.Fields(l.f.rawWord(assign.Value)) {
+			if strings.Contains(field, "://") && strings.Contains(field, pkgver) {
+				l.errorf(assign, hardcodedVersion, field)
+			}
+		}
+	}
+}
+
+// lintURL checks that the given URL is well-formed and doesn't use http.
+func (l *Linter) lintURL(node syntax.Node, rawurl string) {
+	if rawurl == "" {
+		return
+	}
+
+	u, err := url.Parse(rawurl)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		l.errorf(node, malformedURL, rawurl)
+	} else if u.Scheme == "http" {
+		l.errorf(node, insecureURL, rawurl)
+	}
+}
+
+// lintLocalSources checks that all local entries of the source metadata
+// variable refer to files located next to the APKBUILD. Entries whose
+// file name can't be determined statically are not checked.
+func (l *Linter) lintLocalSources() {
+	assign := l.f.lastAssign("source")
+	if assign == nil {
+		return
+	}
+
+	dir := filepath.Dir(l.f.Name())
+	for _, src := range l.f.Sources() {
+		if src.URL != "" || strings.Contains(src.Filename, "$") {
+			continue
+		}
+
+		if !exists(filepath.Join(dir, src.Filename)) {
+			l.errorf(assign, missingLocalSource, src.Filename)
+		}
+	}
+}
+
+// lintDepends checks the entries of all dependency metadata variables.
+// It complains about malformed entries, entries listed multiple times,
+// packages depending on themselves and build time dependencies which
+// are also runtime dependencies. If enabled in the configuration, it
+// also complains about unsorted entries. Values which can't be
+// determined statically are not checked.
+func (l *Linter) lintDepends() {
+	pkgname, _ := l.f.Value("pkgname")
+	depends, _ := l.f.Dependencies("depends")
+
+	for _, varname := range dependVariables {
+		assign := l.f.lastAssign(varname)
+		deps, ok := l.f.Dependencies(varname)
+		if assign == nil || !ok {
+			continue
+		}
+
+		var keys []string
+		for n, dep := range deps {
+			if !isValidDepend(dep) {
+				l.errorf(assign, malformedDepend, dep.Entry, varname)
+				continue
+			}
+
+			key := dep.Key()
+			if isIncluded(keys, key) {
+				l.errorf(assign, duplicateDepend, key, varname)
+				continue
+			}
+			keys = append(keys, key)
+
+			if !dep.Conflict && dep.Prefix == "" && dep.Name == pkgname {
+				l.errorf(assign, selfDepend, varname)
+			}
+			if varname == "makedepends" && !dep.Conflict &&
+				hasDepend(depends, key) {
+				l.errorf(assign, redundantDepend, key, "depends", varname)
+			}
+
+			if l.cfg != nil && l.cfg.SortDepends && n > 0 &&
+				dep.Entry < deps[n-1].Entry {
+				l.errorf(assign, unsortedDepends, dep.Entry, varname,
+					deps[n-1].Entry)
+			}
+		}
+	}
+}
+
+// lintOptions checks that the options metadata variable only contains
+// options supported by abuild(1), each of which is listed only once.
+// Disabling the check function must be explained by a comment preceding
+// the assignment or at the end of it. Values which can't be determined
+// statically are not checked.
+func (l *Linter) lintOptions() {
+	assign := l.f.lastAssign("options")
+	value, ok := l.f.Value("options")
+	if assign == nil || !ok {
+		return
+	}
+
+	var seen []string
+	for _, option := range strings.Fields(value) {
+		if !isIncluded(options, option) {
+			l.errorf(assign, unknownOption, option)
+		}
+
+		if isIncluded(seen, option) {
+			l.errorf(assign, duplicateOption, option)
+			continue
+		}
+		seen = append(seen, option)
+	}
+
+	if isIncluded(seen, "!check") && !l.hasComment(assign.Pos().Line(), assign.End().Line()) {
+		l.error(assign, undocumentedNoCheck)
+	}
+}
+
+// lintDirectives checks that all directives are known and that they
+// only refer to known checks.
+func (l *Linter) lintDirectives() {
+	for _, d := range l.f.badDirectives {
+		l.errorf(&d.c, d.format, d.arg)
+	}
+}
+
+// lintAddressComments checks all global comments which start with given
+// prefix followed by an ascii space character and makes sure that they
+// contain a valid RFC 5322 mail address. It returns the amount of
+// comment that started with the given prefix.
+func (l *Linter) lintAddressComments(prefix string) (int, []addressComment) {
+	var amount int
+	var comments []addressComment
+
+	for _, c := range l.f.Comments {
+		if !strings.HasPrefix(c.Text, prefix) {
+			continue
+		}
+
+		amount++
+		if len(strings.TrimFunc(c.Text, isSpace)) ==
+			len(strings.TrimFunc(prefix, isSpace)) {
+			l.error(&c, missingAddress)
+			continue
+		}
+
+		idx := len(prefix)
+		if c.Text[idx] != ' ' {
+			off := c.Pos().Offset() + 1 + uint(idx)
+			l.fixable(&c, []Edit{{off, off, " "}},
+				noAddressSeparator)
+			continue
+		}
+
+		a, err := mail.ParseAddress(c.Text[idx+1:])
+		if err != nil {
+			l.error(&c, invalidAddress)
+			continue
+		}
+
+		comments = append(comments, addressComment{c, a})
+	}
+
+	return amount, comments
+}
+
+// isValidVarScope reports whether the given literal is included in the
+// given string slice or if it is a global or metadata variable.
+func (l *Linter) isValidVarScope(vars []string, v *syntax.Lit) bool {
+	if isIncluded(vars, v.Value) {
+		return true
+	}
+
+	if !(l.f.IsGlobalVar(v.Value) || l.isMetaVar(v.Value)) {
+		return false
+	}
+
+	return true
+}
+
+// moveToEnd returns edits which move the global statement starting at
+// the given position, including comments on the same line, to the end
+// of the APKBUILD. If the statement can't be moved safely, nil is
+// returned.
+func (l *Linter) moveToEnd(pos syntax.Pos) []Edit {
+	var stmt *syntax.Stmt
+	for _, s := range l.f.prog.Stmts {
+		call, ok := s.Cmd.(*syntax.CallExpr)
+		if ok && s.Pos() == pos && len(call.Args) == 0 &&
+			len(call.Assigns) == 1 && len(s.Redirs) == 0 {
+			stmt = s
+			break
+		}
+	}
+	if stmt == nil {
+		return nil
+	}
+
+	src := l.f.src
+	start, end := stmt.Pos().Offset(), stmt.End().Offset()
+	for start > 0 && src[start-1] != '\n' {
+		start--
+		if !isSpace(rune(src[start])) && src[start] != '\t' {
+			return nil
+		}
+	}
+
+	rest := bytes.TrimLeft(src[end:], " \t")
+	if len(rest) > 0 && rest[0] != '\n' && rest[0] != '#' {
+		return nil
+	}
+	if i := bytes.IndexByte(src[end:], '\n'); i >= 0 {
+		end += uint(i) + 1
+	} else {
+		end = uint(len(src))
+	}
+
+	text := string(src[start:end])
+	if text[len(text)-1] != '\n' {
+		text += "\n"
+	}
+	if len(src) > 0 && src[len(src)-1] != '\n' {
+		text = "\n" + text
+	}
+
+	eof := uint(len(src))
+	return []Edit{{start, end, ""}, {eof, eof, text}}
+}
+
+// metadata returns the metadata variable with the given name. Besides
+// the default metadata variables it also considers additional metadata
+// variables from the configuration of the linter.
+func (l *Linter) metadata(varname string) (metadata, bool) {
+	m, ok := metadataVariables[varname]
+	if !ok && l.cfg != nil && isIncluded(l.cfg.Metadata, varname) {
+		return metadata{beforeFuncs, false}, true
+	}
+
+	return m, ok
+}
+
+// isMetaVar reports whether the given variable is a meta variable.
+func (l *Linter) isMetaVar(varname string) bool {
+	_, ok := l.metadata(varname)
+	return ok
+}
+
+// packageFunctions returns all package functions sorted by invocation
+// time, including additional functions from the configuration.
+func (l *Linter) packageFunctions() []string {
+	if l.cfg == nil {
+		return packageFunctions
+	}
+
+	fns := make([]string, 0, len(packageFunctions)+len(l.cfg.Functions))
+	fns = append(fns, packageFunctions...)
+	return append(fns, l.cfg.Functions...)
+}
+
+// architectures returns all known architectures, including additional
+// architectures from the configuration.
+func (l *Linter) architectures() []string {
+	if l.cfg == nil {
+		return architectures
+	}
+
+	arches := make([]string, 0, len(architectures)+len(l.cfg.Arches))
+	arches = append(arches, architectures...)
+	return append(arches, l.cfg.Arches...)
+}
+
+// severity returns the severity of violations reported by the current
+// check, taking the configuration of the linter into account.
+func (l *Linter) severity() Severity {
+	if l.cfg != nil {
+		sev, ok := l.cfg.Severity[l.c.Info().ID]
+		if ok {
+			return sev
+		}
+	}
+
+	return l.c.Info().Severity
+}
+
+// splitFunctions returns the names of all declared functions used as
+// split functions by subpackages.
+func (l *Linter) splitFunctions() []string {
+	var fns []string
+	for _, subpkg := range l.f.Subpackages() {
+		_, ok := l.f.Functions[subpkg.Split]
+		if ok && !isIncluded(fns, subpkg.Split) {
+			fns = append(fns, subpkg.Split)
+		}
+	}
+
+	return fns
+}
+
+// subpackageAssign returns the first global assignment to the
+// subpackages variable declaring a subpackage with the given split
+// function. If no such assignment is found, e.g. because the split
+// function is derived from a parameter expansion, the last assignment
+// is returned instead.
+func (l *Linter) subpackageAssign(split string) *syntax.Assign {
+	for i, assign := range l.f.Assignments {
+		if assign.Name.Value != "subpackages" || assign.Value == nil {
+			continue
+		}
+
+		for _, field := range strings.Fields(l.f.rawWord(assign.Value)) {
+			if field == "$subpackages" || field == "${subpackages}" {
+				continue
+			}
+			if parseSubpackage(field).Split == split {
+				return &l.f.Assignments[i]
+			}
+		}
+	}
+
+	return l.f.lastAssign("subpackages")
+}
+
+// hasComment reports whether an explanatory global comment is declared
+// on the line preceding the given start line or on the given end line.
+// Empty comments, address comments and directives are not considered
+// explanatory.
+func (l *Linter) hasComment(start, end uint) bool {
+	for _, c := range l.f.Comments {
+		line := c.Pos().Line()
+		if line != start-1 && line != end {
+			continue
+		}
+
+		if strings.TrimFunc(c.Text, isSpace) == "" ||
+			strings.HasPrefix(c.Text, maintainerPrefix) ||
+			strings.HasPrefix(c.Text, contributorPrefix) ||
+			strings.HasPrefix(c.Text, directivePrefix) {
+			continue
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// isValidDepend reports whether the given dependency is well-formed.
+// Unprefixed names starting with a slash are file path dependencies
+// which can't be versioned.
+func isValidDepend(dep Dependency) bool {
+	if dep.Op != "" && (!isIncluded(dependOperators, dep.Op) ||
+		!isDepVersion(dep.Version)) {
+		return false
+	}
+	if strings.Contains(dep.Entry, "@") && !isPkgname(dep.Tag) {
+		return false
+	}
+
+	switch {
+	case dep.Prefix == "" && strings.HasPrefix(dep.Name, "/"):
+		return dep.Op == "" && isDepPath(dep.Name)
+	case dep.Prefix == "":
+		return isPkgname(dep.Name)
+	case dep.Prefix == "so":
+		return isSoname(dep.Name)
+	default:
+		return isDepPrefix(dep.Prefix) && isDepName(dep.Name)
+	}
+}
+
+// hasDepend reports whether the given dependencies contain a runtime
+// dependency with the given name including its prefix.
+func hasDepend(deps []Dependency, key string) bool {
+	for _, dep := range deps {
+		if !dep.Conflict && dep.Key() == key {
+			return true
+		}
+	}
+
+	return false
+}
+
+// findChecksum returns the checksum metadata variable with the given
+// name.
+func findChecksum(varname string) (checksum, bool) {
+	for _, c := range checksumVariables {
+		if c.v == varname {
+			return c, true
+		}
+	}
+
+	return checksum{}, false
+}
+
+// errorf records a style violation at the position of the given node
+// according to format. If the node is nil, the violation doesn't refer
+// to a specific position.
+func (l *Linter) errorf(node syntax.Node, format string,
+	argv ...interface{}) {
+	l.report(node, nil, format, fmt.Sprintf(format, argv...), argv)
+}
+
+// fixablef is like errorf but additionally supplies edits which fix
+// the style violation. If the linter is supposed to fix violations,
+// the edits are recorded instead of reporting the violation.
+func (l *Linter) fixablef(node syntax.Node, fix []Edit, format string,
+	argv ...interface{}) {
+	l.report(node, fix, format, fmt.Sprintf(format, argv...), argv)
+}
+
+// error records a style violation at the position of the given node
+// using the given message, see errorf.
+func (l *Linter) error(node syntax.Node, str string) {
+	l.report(node, nil, str, str, nil)
+}
+
+// fixable is like error but additionally supplies edits which fix the
+// style violation, see fixablef.
+func (l *Linter) fixable(node syntax.Node, fix []Edit, str string) {
+	l.report(node, fix, str, str, nil)
+}
+
+// report records a style violation with the given message spanning the
+// given node.
+func (l *Linter) report(node syntax.Node, fix []Edit, format, msg string,
+	argv []interface{}) {
+	var pos, end syntax.Pos
+	if node != nil {
+		pos, end = node.Pos(), node.End()
+	}
+
+	info := l.c.Info()
+	if l.f.IsDisabled(pos.Line(), info.ID, info.Name) {
+		return
+	} else if l.x && len(fix) > 0 {
+		l.edits = append(l.edits, fix...)
+		return
+	}
+
+	if argv == nil {
+		argv = []interface{}{}
+	}
+
+	l.found = append(l.found, &Diagnostic{
+		Check:     info.ID,
+		Name:      info.Name,
+		File:      l.f.Name(),
+		Line:      pos.Line(),
+		Column:    pos.Col(),
+		EndLine:   end.Line(),
+		EndColumn: end.Col(),
+		Severity:  l.severity(),
+		Message:   msg,
+		Format:    format,
+		Args:      argv,
+		Fix:       fix,
+	})
+}
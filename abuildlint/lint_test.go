@@ -0,0 +1,1385 @@
+package abuildlint
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const name = "Testinput"
+
+type Msg struct {
+	l uint
+	c uint
+	s string
+}
+
+func newLinter(input string) *Linter {
+	reader := strings.NewReader(input)
+	abuild, err := Parse(reader, name)
+	if err != nil {
+		panic(err)
+	}
+
+	linter := Linter{f: abuild}
+	return &linter
+}
+
+func expMsg(t *testing.T, l *Linter, msgs ...Msg) {
+	if len(msgs) != len(l.found) {
+		t.Fatalf("Expected %d violations, got %d",
+			len(msgs), len(l.found))
+	}
+
+	for n, m := range msgs {
+		d := l.found[n]
+		if d.Line != m.l {
+			t.Fatalf("expFail: Line didn't match, expected %d - got %d", m.l, d.Line)
+		}
+		if d.Column != m.c {
+			t.Fatalf("expFail: Column didn't match, expected %d - got %d", m.c, d.Column)
+		}
+		if d.Message != m.s {
+			t.Fatalf("expFail Expected string %q - got %q", m.s, d.Message)
+		}
+	}
+}
+
+func TestLintComments(t *testing.T) {
+	input := `#barfoo
+#
+# foobar
+#	bazbar
+#foobaz`
+
+	l := newLinter(input)
+	l.lint(checkComments)
+
+	expMsg(t, l,
+		Msg{1, 1, badCommentPrefix},
+		Msg{4, 1, badCommentPrefix},
+		Msg{5, 1, badCommentPrefix})
+}
+
+func TestLintAddressComments(t *testing.T) {
+	input := `# foo: foo bar <foo@bar.com>
+# faz:
+# foo:
+# foo:
+# foo:foo bar <foo@bar.com>
+# foo: …`
+
+	l := newLinter(input)
+	l.c = checkAddressComments
+	n, addrs := l.lintAddressComments(" foo:")
+	if n != 5 || len(addrs) != 1 || len(l.found) == 0 {
+		t.Fail()
+	}
+
+	if addrs[0].a.String() != "\"foo bar\" <foo@bar.com>" {
+		t.Fatalf("Expected %q - got %q", "foo",
+			addrs[0].a.String())
+	}
+
+	expMsg(t, l,
+		Msg{3, 1, missingAddress},
+		Msg{4, 1, missingAddress},
+		Msg{5, 1, noAddressSeparator},
+		Msg{6, 1, invalidAddress})
+}
+
+func TestLintMaintainerAndContributors(t *testing.T) {
+	t.Run("missingMaintainer", func(t *testing.T) {
+		l := newLinter("")
+		l.lint(checkAddressComments)
+		expMsg(t, l, Msg{0, 0, missingMaintainer})
+	})
+
+	t.Run("emptyMaintainer", func(t *testing.T) {
+		l := newLinter("# Maintainer:")
+		l.lint(checkAddressComments)
+		expMsg(t, l, Msg{1, 1, missingAddress})
+	})
+
+	t.Run("tooManyMaintainers", func(t *testing.T) {
+		l := newLinter(`# Maintainer: A <a@a>
+# Maintainer: B <b@b>`)
+		l.lint(checkAddressComments)
+		expMsg(t, l, Msg{2, 1, tooManyMaintainers})
+	})
+
+	t.Run("maintainerAfterAssign", func(t *testing.T) {
+		l := newLinter(`pkgname=foo
+# Maintainer: A <a@b>`)
+		l.lint(checkAddressComments)
+		expMsg(t, l, Msg{2, 1, maintainerAfterAssign})
+	})
+
+	t.Run("wrongAddrCommentOrder", func(t *testing.T) {
+		l := newLinter(`# Maintainer: A <a@b>
+# Contributor: B <b@c>`)
+		l.lint(checkAddressComments)
+		expMsg(t, l, Msg{2, 1, wrongAddrCommentOrder})
+	})
+
+	t.Run("repeatedAddrComment", func(t *testing.T) {
+		l := newLinter(`# Contributor: A <a@b>
+# Contributor: A <a@b>
+# Maintainer: M <m@m>`)
+		l.lint(checkAddressComments)
+		expMsg(t, l, Msg{2, 1, repeatedAddrComment})
+	})
+
+	t.Run("oneMaintainer", func(t *testing.T) {
+		l := newLinter("# Maintainer: J <a@k>")
+		l.lint(checkAddressComments)
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+
+	t.Run("oneMaintainerAndContributors", func(t *testing.T) {
+		l := newLinter(`# Contributor: A <a@a>
+# Contributor: B <b@b>
+# Maintainer: C <c@c>`)
+		l.lint(checkAddressComments)
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+}
+
+func TestListGlobalVariables(t *testing.T) {
+	input := `pkgname=foobar
+foo=42
+_foo=9001
+__foo=bar
+export ENV=23`
+
+	l := newLinter(input)
+	l.lint(checkGlobalVariables)
+
+	expMsg(t, l,
+		Msg{2, 1, fmt.Sprintf(invalidGlobalVar, "foo")},
+		Msg{4, 1, fmt.Sprintf(invalidGlobalVar, "__foo")})
+}
+
+func TestLintUnusedVariables(t *testing.T) {
+	input := `pkgname=foobar
+_foo=23
+_bar=42
+f1() {
+foo=lol
+}
+f2() {
+echo $_bar
+}
+f3() {
+FOO=bar make
+}
+f4() {
+export ENV=42
+}`
+
+	l := newLinter(input)
+	l.lint(checkUnusedVariables)
+
+	expMsg(t, l,
+		Msg{2, 1, fmt.Sprintf(variableUnused, "_foo")},
+		Msg{5, 1, fmt.Sprintf(variableUnused, "foo")})
+}
+
+func TestLintGlobalCmdSubsts(t *testing.T) {
+	input := `pkgname=bar
+_bar=$(ls)
+f1() {
+local v1=${_bar}
+}
+_baz=$(cp -h)
+f2() {
+local v2=${_baz}
+}
+_baz=${foo} bar`
+
+	l := newLinter(input)
+	l.lint(checkGlobalCmdSubsts)
+
+	expMsg(t, l,
+		Msg{2, 6, cmdSubstInGlobalVar},
+		Msg{6, 6, cmdSubstInGlobalVar})
+}
+
+func TestLintLocalVariables(t *testing.T) {
+	input := `f1() {
+foo=123
+}
+f2() {
+local foo=123
+}
+f3() {
+local bar=456
+}
+f4() {
+for foobar in "a" "b" "c"; do echo "$foobar"; done
+}
+f5() {
+export foo="bar"; echo "$foo"
+}
+VARFORCALLEXPR=23 ls`
+
+	l := newLinter(input)
+	l.lint(checkLocalVariables)
+
+	expMsg(t, l,
+		Msg{2, 1, fmt.Sprintf(nonLocalVariable, "foo")},
+		Msg{11, 5, fmt.Sprintf(nonLocalVariable, "foobar")})
+}
+
+func TestLintParamExpression(t *testing.T) {
+	input := `# foobar
+foo=${pkgname}
+bar=$foo
+# barfoo
+foo=${pkgname##.*}
+foo=${foobar}foobar
+foo=${foobar}.$barfoo
+foo=${foobar}src.tar.gz`
+
+	l := newLinter(input)
+	l.lint(checkParamExpression)
+
+	expMsg(t, l,
+		Msg{2, 5, fmt.Sprintf(trivialLongParamExp, "pkgname", "pkgname")},
+		Msg{7, 5, fmt.Sprintf(trivialLongParamExp, "foobar", "foobar")})
+}
+
+func TestVariablePlacement(t *testing.T) {
+	input := `sha512sums=foobar
+myfunc() {
+echo myfunc
+}
+pkgname=barfoo`
+
+	l := newLinter(input)
+	l.lint(checkMetadataPlacement)
+
+	expMsg(t, l,
+		Msg{1, 1, fmt.Sprintf(metadataAfterFunc, "sha512sums")},
+		Msg{5, 1, fmt.Sprintf(metadataBeforeFunc, "pkgname")})
+}
+
+func TestLintRequiredMetadata(t *testing.T) {
+	t.Run("allVarsDefined", func(t *testing.T) {
+		input := `pkgname=foobar
+pkgver=1337
+pkgrel=2342
+pkgdesc="foobar"
+url=http://example.org
+arch=all
+license=MIT
+sha512sums=1234`
+
+		l := newLinter(input)
+		l.lint(checkRequiredMetadata)
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+
+	t.Run("missingOneVar", func(t *testing.T) {
+		input := `pkgname=foobar
+pkgrel=2342
+pkgdesc="foobar"
+url=http://example.org
+arch=all
+license=MIT
+sha512sums=1234`
+
+		l := newLinter(input)
+		l.lint(checkRequiredMetadata)
+
+		expMsg(t, l,
+			Msg{0, 0, fmt.Sprintf(missingMetadata, "pkgver")})
+	})
+}
+
+func TestLintFunctionOrder(t *testing.T) {
+	t.Run("wrongFuncOrder", func(t *testing.T) {
+		input := `package() {
+}
+build() {
+}`
+
+		l := newLinter(input)
+		l.lint(checkFunctionOrder)
+
+		expMsg(t, l,
+			Msg{1, 1, fmt.Sprintf(wrongFuncOrder, "package", "build")})
+	})
+
+	t.Run("rightFuncOrder", func(t *testing.T) {
+		input := `prepare() {
+}
+build() {
+}
+check() {
+}
+package() {
+}`
+
+		l := newLinter(input)
+		l.lint(checkFunctionOrder)
+
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+}
+
+func TestLintSubpackageFunctionOrder(t *testing.T) {
+	input := `subpackages="$pkgname-doc $pkgname-foo"
+foo() {
+}
+package() {
+}
+doc() {
+}`
+
+	l := newLinter(input)
+	l.lint(checkFunctionOrder)
+
+	expMsg(t, l,
+		Msg{2, 1, fmt.Sprintf(wrongFuncOrder, "foo", "package")})
+}
+
+func TestLintSubpackages(t *testing.T) {
+	input := `subpackages="$pkgname-doc $pkgname-dev"
+subpackages="$subpackages py3-$pkgname:_py3:noarch ${pkgname}-bar"
+subpackages="$subpackages $pkgname-baz:custom"
+package() {
+}
+dev() {
+}
+_py3() {
+}
+_helper() {
+}
+unused() {
+}`
+
+	l := newLinter(input)
+	l.lint(checkSubpackages)
+
+	expMsg(t, l,
+		Msg{2, 1, fmt.Sprintf(missingSplitFunc, "bar", "${pkgname}-bar")},
+		Msg{3, 1, fmt.Sprintf(missingSplitFunc, "custom", "$pkgname-baz")},
+		Msg{12, 1, fmt.Sprintf(unusedSplitFunc, "unused")})
+
+	input = `pkgname=foo
+subpackages="$pkgname-doc"
+# abuild-lint: disable=split-function
+subpackages="$subpackages $pkgname-bar"`
+
+	l = newLinter(input)
+	l.lint(checkSubpackages)
+
+	expMsg(t, l)
+}
+
+func TestParseSubpackage(t *testing.T) {
+	tests := []struct {
+		entry  string
+		subpkg Subpackage
+	}{
+		{"foo", Subpackage{"foo", "foo", ""}},
+		{"$pkgname-doc", Subpackage{"$pkgname-doc", "doc", ""}},
+		{"foo-bar:baz", Subpackage{"foo-bar", "baz", ""}},
+		{"foo-bar::noarch", Subpackage{"foo-bar", "bar", "noarch"}},
+		{"foo:bar:noarch", Subpackage{"foo", "bar", "noarch"}},
+	}
+
+	for _, test := range tests {
+		subpkg := parseSubpackage(test.entry)
+		if subpkg != test.subpkg {
+			t.Fatalf("Expected %v - got %v", test.subpkg, subpkg)
+		}
+	}
+}
+
+func TestLintChecksums(t *testing.T) {
+	d1 := strings.Repeat("a", 128)
+	d2 := strings.Repeat("b", 128)
+
+	t.Run("consistent", func(t *testing.T) {
+		input := `source="$pkgname-$pkgver.tar.gz::https://example.org/v$pkgver.tar.gz
+	https://example.org/${pkgname}-fix.patch
+	foo.initd"
+build() {
+}
+sha512sums="` + d1 + `  foo-1.0.tar.gz
+` + d2 + `  foo-fix.patch
+` + d1 + `  foo.initd"`
+
+		l := newLinter(input)
+		l.lint(checkChecksums)
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+
+	t.Run("inconsistent", func(t *testing.T) {
+		input := `source="a.patch b.patch c.patch d.patch"
+sha512sums="` + d1 + `  b.patch
+` + d2 + `  a.patch
+abcdef  c.patch
+` + d1 + `  e.patch
+` + d1 + `"`
+
+		l := newLinter(input)
+		l.lint(checkChecksums)
+
+		expMsg(t, l,
+			Msg{2, 1, fmt.Sprintf(wrongChecksumOrder, "a.patch", "b.patch")},
+			Msg{2, 1, fmt.Sprintf(invalidDigest, "c.patch", 128)},
+			Msg{2, 1, fmt.Sprintf(unknownChecksum, "e.patch")},
+			Msg{2, 1, fmt.Sprintf(malformedChecksum, d1)},
+			Msg{2, 1, fmt.Sprintf(missingChecksum, "d.patch", "sha512sums")})
+	})
+}
+
+func TestLintDeprecatedChecksums(t *testing.T) {
+	input := `md5sums=foo
+sha512sums=bar
+sha256sums=baz
+sha512sums=bar`
+
+	l := newLinter(input)
+	l.lint(checkDeprecatedChecksums)
+
+	expMsg(t, l,
+		Msg{1, 1, fmt.Sprintf(deprecatedChecksum, "md5sums", "sha512sums")},
+		Msg{2, 1, fmt.Sprintf(multipleChecksums, "sha512sums", "md5sums")},
+		Msg{3, 1, fmt.Sprintf(deprecatedChecksum, "sha256sums", "sha512sums")},
+		Msg{3, 1, fmt.Sprintf(multipleChecksums, "sha256sums", "md5sums")},
+		Msg{4, 1, fmt.Sprintf(multipleChecksums, "sha512sums", "md5sums")})
+}
+
+func TestLintPackageFields(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, input := range []string{
+			"pkgname=py3-foo_bar+baz.1\npkgver=1.2.3a_rc1_p20180101\npkgrel=0",
+			"pkgname=foo\npkgver=2018.01_git\npkgrel=12",
+			"pkgname=foo\n_ver=1.0\npkgver=${_ver}_beta2\npkgrel=$_rel",
+		} {
+			l := newLinter(input)
+			l.lint(checkPackageFields)
+			if len(l.found) > 0 {
+				t.Fatalf("Unexpected violation for %q", input)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		input := `pkgname=Foo
+pkgver=1.0-beta
+pkgrel=r1`
+
+		l := newLinter(input)
+		l.lint(checkPackageFields)
+
+		expMsg(t, l,
+			Msg{1, 1, fmt.Sprintf(invalidPkgname, "Foo")},
+			Msg{2, 1, fmt.Sprintf(invalidPkgver, "1.0-beta")},
+			Msg{3, 1, fmt.Sprintf(invalidPkgrel, "r1")})
+	})
+}
+
+func TestParseLicense(t *testing.T) {
+	licenses, exceptions, err := ParseLicense(
+		"(MIT OR Apache-2.0) AND GPL-2.0-or-later WITH Classpath-exception-2.0")
+	if err != nil {
+		t.Fatal("ParseLicense failed:", err)
+	}
+
+	if strings.Join(licenses, " ") != "MIT Apache-2.0 GPL-2.0-or-later" {
+		t.Fatalf("Unexpected licenses %v", licenses)
+	}
+	if len(exceptions) != 1 || exceptions[0] != "Classpath-exception-2.0" {
+		t.Fatalf("Unexpected exceptions %v", exceptions)
+	}
+
+	for _, expr := range []string{"", "MIT AND", "(MIT", "MIT)",
+		"MIT BSD", "MIT WITH", "OR MIT", "MIT and GPL-2.0-only"} {
+		if _, _, err := ParseLicense(expr); err == nil {
+			t.Fatalf("Expected error for %q", expr)
+		}
+	}
+}
+
+func TestLintLicense(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, input := range []string{
+			`license="MIT AND (LGPL-2.1-or-later OR LicenseRef-foo)"`,
+			`license="(MIT OR Apache-2.0) AND Unicode-3.0"`,
+			`license="Elastic-2.0 OR Python-2.0.1 OR MIT-Khronos-old"`,
+			`license="mit AND apache-2.0 WITH llvm-exception"`} {
+			l := newLinter(input)
+			l.lint(checkLicense)
+			if len(l.found) > 0 {
+				t.Fatalf("Unexpected violation for %q", input)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		input := `license="GPL2+ AND custom AND GPL-2.0-only WITH foo-exception"
+license="MIT BSD"`
+
+		l := newLinter(input)
+		l.lint(checkLicense)
+
+		expMsg(t, l,
+			Msg{2, 1, fmt.Sprintf(invalidLicenseExpr, "MIT BSD", `unexpected "BSD"`)})
+
+		var buf bytes.Buffer
+		if err := FormatJSON(&buf, l.found[0]); err != nil {
+			t.Fatal("FormatJSON failed:", err)
+		}
+
+		var v Diagnostic
+		if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+			t.Fatal("json.Unmarshal failed:", err)
+		}
+		if len(v.Args) != 2 || v.Args[0] != "MIT BSD" ||
+			v.Args[1] != `unexpected "BSD"` {
+			t.Fatalf("Unexpected arguments %v", v.Args)
+		}
+
+		l = newLinter(strings.Split(input, "\n")[0])
+		l.lint(checkLicense)
+
+		expMsg(t, l,
+			Msg{1, 1, fmt.Sprintf(legacyLicense, "GPL2+", "GPL-2.0-or-later")},
+			Msg{1, 1, fmt.Sprintf(unknownLicense, "custom")},
+			Msg{1, 1, fmt.Sprintf(unknownException, "foo-exception")})
+	})
+
+	t.Run("deprecated", func(t *testing.T) {
+		input := `license="GPL-2.0-with-classpath-exception AND Net-SNMP AND
+MIT WITH Nokia-Qt-exception-1.1 AND gpl-3.0+"`
+
+		l := newLinter(input)
+		l.lint(checkLicense)
+
+		expMsg(t, l,
+			Msg{1, 1, fmt.Sprintf(legacyLicense, "GPL-2.0-with-classpath-exception",
+				"GPL-2.0-only WITH Classpath-exception-2.0")},
+			Msg{1, 1, fmt.Sprintf(deprecatedLicense, "Net-SNMP")},
+			Msg{1, 1, fmt.Sprintf(legacyLicense, "gpl-3.0+", "GPL-3.0-or-later")},
+			Msg{1, 1, fmt.Sprintf(deprecatedException, "Nokia-Qt-exception-1.1")})
+	})
+
+	t.Run("replacements", func(t *testing.T) {
+		for id, repl := range legacyLicenses {
+			licenses, exceptions, err := ParseLicense(repl)
+			if err != nil {
+				t.Fatalf("Replacement %q for %q is invalid: %s", repl, id, err)
+			}
+			for _, license := range licenses {
+				if !IsLicense(license) {
+					t.Fatalf("Replacement %q for %q is unknown", license, id)
+				}
+			}
+			for _, exception := range exceptions {
+				if !isException(exception) {
+					t.Fatalf("Replacement %q for %q is unknown", exception, id)
+				}
+			}
+		}
+	})
+}
+
+func TestLintArch(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, input := range []string{`arch="all !s390x !riscv64"`,
+			`arch="noarch"`, `arch="x86_64 aarch64"`} {
+			l := newLinter(input)
+			l.lint(checkArch)
+			if len(l.found) > 0 {
+				t.Fatalf("Unexpected violation for %q", input)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		input := `arch="noarch x86_64 !foo !all x86_64"`
+
+		l := newLinter(input)
+		l.lint(checkArch)
+
+		expMsg(t, l,
+			Msg{1, 1, fmt.Sprintf(unknownArch, "foo")},
+			Msg{1, 1, fmt.Sprintf(negatedArch, "all")},
+			Msg{1, 1, fmt.Sprintf(duplicateArch, "x86_64")},
+			Msg{1, 1, noarchCombined})
+	})
+
+	t.Run("config", func(t *testing.T) {
+		l := newLinter(`arch="x86_64 loongarch64"`)
+		l.cfg = &Config{Arches: []string{"loongarch64"}}
+		l.lint(checkArch)
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+}
+
+func TestLintPkgdesc(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, input := range []string{`pkgdesc="Lightweight foo library"`,
+			`pkgdesc="Things to do..."`, `pkgdesc="Another ${pkgname}"`} {
+			l := newLinter("pkgname=foo\n" + input)
+			l.lint(checkPkgdesc)
+			if len(l.found) > 0 {
+				t.Fatalf("Unexpected violation for %q", input)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		tests := []struct {
+			pkgdesc string
+			msgs    []Msg
+		}{
+			{`"$pkgname is a library. "`, []Msg{
+				{2, 1, pkgdescSpace},
+				{2, 1, pkgdescPkgname}}},
+			{`" The foo library."`, []Msg{
+				{2, 1, pkgdescSpace},
+				{2, 1, pkgdescPeriod},
+				{2, 1, pkgdescArticle}}},
+			{strings.Repeat("x", maxPkgdescLen+1), []Msg{
+				{2, 1, fmt.Sprintf(pkgdescTooLong, maxPkgdescLen)}}},
+		}
+
+		for _, test := range tests {
+			l := newLinter("pkgname=foo\npkgdesc=" + test.pkgdesc)
+			l.lint(checkPkgdesc)
+			expMsg(t, l, test.msgs...)
+		}
+	})
+}
+
+func TestLintURLs(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		input := `pkgname=foo
+pkgver=1.2.3
+url="https://example.org"
+source="https://example.org/$pkgname-$pkgver.tar.gz
+	bar-1.2::git+https://example.org/bar.git
+	foo.patch"`
+
+		l := newLinter(input)
+		l.lint(checkURLs)
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		input := `pkgver=1.2.3
+url="http://example.org"
+source="https://example.org/foo-1.2.3.tar.gz
+	foo.tar.gz::https:///foo.tar.gz"`
+
+		l := newLinter(input)
+		l.lint(checkURLs)
+
+		expMsg(t, l,
+			Msg{2, 1, fmt.Sprintf(insecureURL, "http://example.org")},
+			Msg{3, 1, fmt.Sprintf(malformedURL, "https:///foo.tar.gz")},
+			Msg{3, 1, fmt.Sprintf(hardcodedVersion,
+				"https://example.org/foo-1.2.3.tar.gz")})
+	})
+}
+
+func TestLintLocalSources(t *testing.T) {
+	dir, err := ioutil.TempDir("", "abuild-lint")
+	if err != nil {
+		t.Fatal("TempDir failed:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "foo.patch"), nil, 0644)
+	if err != nil {
+		t.Fatal("WriteFile failed:", err)
+	}
+
+	input := `source="https://example.org/foo.tar.gz
+	foo.patch
+	bar.patch
+	$pkgname.initd"`
+
+	abuild, err := Parse(strings.NewReader(input),
+		filepath.Join(dir, "APKBUILD"))
+	if err != nil {
+		t.Fatal("Parse failed:", err)
+	}
+
+	l := &Linter{f: abuild}
+	l.lint(checkLocalSources)
+
+	expMsg(t, l,
+		Msg{1, 1, fmt.Sprintf(missingLocalSource, "bar.patch")})
+}
+
+func TestParseDependency(t *testing.T) {
+	dep := parseDependency("!so:libfoo.so.1@testing>=1.2-r3")
+	expected := Dependency{"!so:libfoo.so.1@testing>=1.2-r3", true,
+		"so", "libfoo.so.1", "testing", ">=", "1.2-r3"}
+
+	if dep != expected {
+		t.Fatalf("Expected %v - got %v", expected, dep)
+	}
+}
+
+func TestLintDepends(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		input := `pkgname=foo
+depends="bar so:libbar.so.1 cmd:bar pc:bar>=1.0 !foo-old py3-baz~1.2 /bin/sh"
+makedepends="baz-dev qux>1.2_rc1-r0"`
+
+		l := newLinter(input)
+		l.lint(checkDepends)
+		if len(l.found) > 0 {
+			t.Fail()
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		input := `pkgname=foo
+depends="bar foo bar>=1 baz>= so:libbaz cmd:/bin/baz pc: qux<=>1 /bin/ /bin/sh>=1"
+makedepends="bar !foo"`
+
+		l := newLinter(input)
+		l.lint(checkDepends)
+
+		expMsg(t, l,
+			Msg{2, 1, fmt.Sprintf(selfDepend, "depends")},
+			Msg{2, 1, fmt.Sprintf(duplicateDepend, "bar", "depends")},
+			Msg{2, 1, fmt.Sprintf(malformedDepend, "baz>=", "depends")},
+			Msg{2, 1, fmt.Sprintf(malformedDepend, "so:libbaz", "depends")},
+			Msg{2, 1, fmt.Sprintf(malformedDepend, "cmd:/bin/baz", "depends")},
+			Msg{2, 1, fmt.Sprintf(malformedDepend, "pc:", "depends")},
+			Msg{2, 1, fmt.Sprintf(malformedDepend, "qux<=>1", "depends")},
+			Msg{2, 1, fmt.Sprintf(malformedDepend, "/bin/", "depends")},
+			Msg{2, 1, fmt.Sprintf(malformedDepend, "/bin/sh>=1", "depends")},
+			Msg{3, 1, fmt.Sprintf(redundantDepend, "bar", "depends", "makedepends")})
+	})
+
+	t.Run("sorted", func(t *testing.T) {
+		input := `makedepends="bar foo baz"`
+
+		l := newLinter(input)
+		l.cfg = &Config{SortDepends: true}
+		l.lint(checkDepends)
+
+		expMsg(t, l,
+			Msg{1, 1, fmt.Sprintf(unsortedDepends, "baz", "makedepends", "foo")})
+	})
+}
+
+func TestLintOptions(t *testing.T) {
+	t.Run("valid", func(t *testing.T) {
+		for _, input := range []string{
+			"# tests require network access\noptions=\"net !check\"",
+			"options=\"!check\" # no test suite",
+			"options=\"suid !strip\""} {
+			l := newLinter(input)
+			l.lint(checkOptions)
+			if len(l.found) > 0 {
+				t.Fatalf("Unexpected violation for %q", input)
+			}
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		input := `# foo
+
+options="!check foo net net"`
+
+		l := newLinter(input)
+		l.lint(checkOptions)
+
+		expMsg(t, l,
+			Msg{3, 1, fmt.Sprintf(unknownOption, "foo")},
+			Msg{3, 1, fmt.Sprintf(duplicateOption, "net")},
+			Msg{3, 1, undocumentedNoCheck})
+	})
+
+	t.Run("undocumented", func(t *testing.T) {
+		for _, input := range []string{
+			"# Maintainer: A <a@b>\noptions=\"!check\"",
+			"# Contributor: A <a@b>\noptions=\"!check\"",
+			"# abuild-lint: disable=unused-variable\noptions=\"!check\"",
+			"#\noptions=\"!check\"",
+			"options=\"!check\" # abuild-lint: disable=AL006"} {
+			l := newLinter(input)
+			l.lint(checkOptions)
+
+			line := uint(strings.Count(input, "\n") + 1)
+			expMsg(t, l, Msg{line, 1, undocumentedNoCheck})
+		}
+	})
+}
+
+func TestLintBashisms(t *testing.T) {
+	input := `[[ -e "$builddir" ]] && foo=bar
+bar=*(foo bar)
+echo >(true)
+let x
+declare -A foobar
+readonly x
+typeset -r x
+nameref foo
+echo ${#foo}
+select s in foo bar baz; do
+	echo $s
+done
+function f() {
+return 1
+}`
+
+	l := newLinter(input)
+	l.lint(checkBashisms)
+
+	expMsg(t, l,
+		Msg{1, 1, fmt.Sprintf(forbiddenBashism, "test clause")},
+		Msg{2, 5, fmt.Sprintf(forbiddenBashism, "extended globbing expression")},
+		Msg{3, 6, fmt.Sprintf(forbiddenBashism, "process substitution")},
+		Msg{4, 1, fmt.Sprintf(forbiddenBashism, "let clause")},
+		Msg{5, 1, fmt.Sprintf(forbiddenBashism, "declare")},
+		Msg{6, 1, fmt.Sprintf(forbiddenBashism, "readonly")},
+		Msg{7, 1, fmt.Sprintf(forbiddenBashism, "typeset")},
+		Msg{8, 1, fmt.Sprintf(forbiddenBashism, "nameref")},
+		Msg{9, 6, fmt.Sprintf(forbiddenBashism, "advanced parameter expression")},
+		Msg{10, 1, fmt.Sprintf(forbiddenBashism, "select clause")},
+		Msg{13, 1, fmt.Sprintf(forbiddenBashism, "non-POSIX function declaration")})
+}
+
+func TestFindCheck(t *testing.T) {
+	ids := make(map[string]bool)
+	for _, c := range Checks() {
+		info := c.Info()
+		if ids[info.ID] || ids[info.Name] {
+			t.Fatalf("Check %s (%s) is not unique", info.ID, info.Name)
+		}
+		ids[info.ID], ids[info.Name] = true, true
+
+		if FindCheck(info.ID) != c || FindCheck(info.Name) != c {
+			t.Fatalf("Couldn't find check %s (%s)", info.ID, info.Name)
+		}
+	}
+
+	if FindCheck("foobar") != nil {
+		t.Fail()
+	}
+}
+
+// assignCheck is a check implemented without access to the linter
+// internals. It reports all global variable assignments.
+type assignCheck struct{}
+
+func (assignCheck) Info() CheckInfo {
+	return CheckInfo{"XY001", "assignment", "Variables aren't assigned",
+		SeverityInfo, false}
+}
+
+func (assignCheck) Run(l *Linter) {
+	assigns := l.APKBUILD().Assignments
+	for i := range assigns {
+		l.Reportf(&assigns[i], "assignment to %q", assigns[i].Name.Value)
+	}
+}
+
+func TestRegister(t *testing.T) {
+	registered := Checks()
+	defer func() { registry = registered }()
+
+	Register(assignCheck{})
+	cs := Checks()
+	if len(cs) != len(registered)+1 || cs[len(cs)-1] != Check(assignCheck{}) {
+		t.Fatal("Expected check to be registered last")
+	}
+	if FindCheck("assignment") != Check(assignCheck{}) {
+		t.Fatal("Couldn't find registered check")
+	}
+
+	l := NewLinter(newLinter("_foo=23\n_bar=42").f, Options{
+		Enabled: map[string]bool{"XY001": true},
+	})
+	diags := l.Lint()
+	if len(diags) != 2 {
+		t.Fatalf("Expected 2 violations, got %d", len(diags))
+	}
+	if d := diags[1]; d.Check != "XY001" || d.Line != 2 ||
+		d.Severity != SeverityInfo || d.Message != `assignment to "_bar"` {
+		t.Fatalf("Unexpected violation %v", d)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Expected panic for duplicate check")
+		}
+	}()
+	Register(assignCheck{})
+}
+
+// infoCheck is a check with the given metadata which never reports a
+// style violation.
+type infoCheck CheckInfo
+
+func (c infoCheck) Info() CheckInfo {
+	return CheckInfo(c)
+}
+
+func (infoCheck) Run(l *Linter) {}
+
+func TestRegisterInvalid(t *testing.T) {
+	registered := Checks()
+	defer func() { registry = registered }()
+
+	for _, info := range []CheckInfo{
+		{"", "foo", "", SeverityInfo, false},
+		{"XY002", "", "", SeverityInfo, false},
+		{"XY003", "comment-prefix", "", SeverityInfo, false},
+		{"XY004", "foo", "", SeverityError + 1, false},
+		{"XY005", "foo", "", -1, false}} {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Fatalf("Expected panic for %v", info)
+				}
+			}()
+			Register(infoCheck(info))
+		}()
+	}
+}
+
+func TestParseChecks(t *testing.T) {
+	cs, err := ParseChecks([]string{"AL001", " unused-variable", "", "bashism"})
+	if err != nil {
+		t.Fatal("ParseChecks failed:", err)
+	}
+
+	if len(cs) != 3 || cs[0] != checkComments ||
+		cs[1] != checkUnusedVariables || cs[2] != checkBashisms {
+		t.Fatalf("Unexpected checks %v", cs)
+	}
+
+	if _, err := ParseChecks([]string{"AL001", "foobar"}); err == nil {
+		t.Fatal("Expected error for unknown check")
+	}
+}
+
+func TestSelectChecks(t *testing.T) {
+	enabled, err := SelectChecks(nil, []string{"AL001"})
+	if err != nil {
+		t.Fatal("SelectChecks failed:", err)
+	}
+	if enabled[checkComments.ID] || !enabled[checkBashisms.ID] {
+		t.Fatalf("Unexpected checks %v", enabled)
+	}
+
+	for _, list := range []string{"AL000", "parse-error"} {
+		if _, err := SelectChecks([]string{list}, nil); err == nil {
+			t.Fatalf("Expected error for enabling %q", list)
+		}
+		if _, err := SelectChecks(nil, []string{list}); err == nil {
+			t.Fatalf("Expected error for disabling %q", list)
+		}
+	}
+}
+
+func TestLintEnabledChecks(t *testing.T) {
+	input := `#foo
+package() {
+}
+build() {
+}`
+
+	l := newLinter(input)
+	l.e = map[string]bool{checkComments.ID: true}
+	l.Lint()
+
+	expMsg(t, l, Msg{1, 1, badCommentPrefix})
+}
+
+func TestDisableDirectives(t *testing.T) {
+	t.Run("nextLine", func(t *testing.T) {
+		input := `# abuild-lint: disable=unused-variable
+_foo=23
+_bar=42
+_baz=9001 # abuild-lint: disable=AL006`
+
+		l := newLinter(input)
+		l.lint(checkUnusedVariables)
+
+		expMsg(t, l, Msg{3, 1, fmt.Sprintf(variableUnused, "_bar")})
+	})
+
+	t.Run("fileScope", func(t *testing.T) {
+		input := `# abuild-lint: disable-file=unused-variable,required-metadata
+_foo=23
+
+foo=42`
+
+		l := newLinter(input)
+		l.lint(checkUnusedVariables, checkRequiredMetadata,
+			checkGlobalVariables)
+
+		expMsg(t, l, Msg{4, 1, fmt.Sprintf(invalidGlobalVar, "foo")})
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		input := `# abuild-lint: disable=unused-varible,AL006
+_foo=23
+_bar=42 # abuild-lint: enable=AL006 disable-file=`
+
+		l := newLinter(input)
+		l.lint(checkUnusedVariables, checkDirectives)
+
+		expMsg(t, l,
+			Msg{1, 1, fmt.Sprintf(unknownDirectiveCheck, "unused-varible")},
+			Msg{3, 1, fmt.Sprintf(variableUnused, "_bar")},
+			Msg{3, 9, fmt.Sprintf(unknownDirective, "enable=AL006")})
+	})
+
+	t.Run("blankLine", func(t *testing.T) {
+		input := `# abuild-lint: disable=unused-variable
+
+_foo=23
+_bar=42`
+
+		l := newLinter(input)
+		l.lint(checkUnusedVariables)
+
+		expMsg(t, l,
+			Msg{3, 1, fmt.Sprintf(variableUnused, "_foo")},
+			Msg{4, 1, fmt.Sprintf(variableUnused, "_bar")})
+	})
+}
+
+func TestFix(t *testing.T) {
+	input := `# Maintainer:A <a@b>
+#foo
+sha512sums="1234  foo.tar.gz" # checksums
+_foo=bar
+build() {
+	echo ${_foo}
+	echo ${_foo}bar.baz
+}`
+	expected := `# Maintainer: A <a@b>
+# foo
+_foo=bar
+build() {
+	echo $_foo
+	echo ${_foo}bar.baz
+}
+sha512sums="1234  foo.tar.gz" # checksums
+`
+
+	l := newLinter(input)
+	l.x = true
+	for _, c := range []Check{checkComments, checkAddressComments,
+		checkParamExpression, checkMetadataPlacement} {
+		l.lint(c)
+	}
+
+	src, fixed := l.Fix()
+	if !fixed || len(l.found) > 0 {
+		t.Fatal("Expected all violations to be fixed")
+	}
+	if string(src) != expected {
+		t.Fatalf("Expected %q - got %q", expected, src)
+	}
+}
+
+func TestWriteDiff(t *testing.T) {
+	var buf bytes.Buffer
+
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\n"
+	new := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\n"
+	expected := `--- a/APKBUILD
++++ b/APKBUILD
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -7,3 +7,4 @@
+ g
+ h
+ i
++j
+`
+
+	err := WriteDiff(&buf, "APKBUILD", []byte(old), []byte(new))
+	if err != nil {
+		t.Fatal("WriteDiff failed:", err)
+	}
+	if buf.String() != expected {
+		t.Fatalf("Expected %q - got %q", expected, buf.String())
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	input := `# abuild-lint configuration
+disable = ["function-order"] # legacy aports
+metadata = [
+	"_extra", # consumed by a helper
+	'pkgextra',
+]
+functions = ["snapshot2"]
+arches = ["loongarch64"]
+sort-depends = true
+
+[severity]
+param-expansion = "info"`
+
+	cfg, err := ParseConfig(strings.NewReader(input), name)
+	if err != nil {
+		t.Fatal("ParseConfig failed:", err)
+	}
+
+	if len(cfg.Enable) != 0 || len(cfg.Disable) != 1 ||
+		cfg.Disable[0] != "function-order" {
+		t.Fatalf("Unexpected check selection %v %v", cfg.Enable, cfg.Disable)
+	}
+	if len(cfg.Metadata) != 2 || cfg.Metadata[1] != "pkgextra" {
+		t.Fatalf("Unexpected metadata %v", cfg.Metadata)
+	}
+	if len(cfg.Functions) != 1 || cfg.Functions[0] != "snapshot2" {
+		t.Fatalf("Unexpected functions %v", cfg.Functions)
+	}
+	if len(cfg.Arches) != 1 || cfg.Arches[0] != "loongarch64" {
+		t.Fatalf("Unexpected architectures %v", cfg.Arches)
+	}
+	if !cfg.SortDepends {
+		t.Fatal("Expected sort-depends to be enabled")
+	}
+	if len(cfg.Severity) != 1 ||
+		cfg.Severity[checkParamExpression.ID] != SeverityInfo {
+		t.Fatalf("Unexpected severities %v", cfg.Severity)
+	}
+
+	for _, input := range []string{"foo = []", "enable = [\"foo\"]",
+		"disable = true", "enable = [\"AL001\"", "sort-depends = []",
+		"[severity]\nfoo = \"error\"", "[severity]\nAL001 = \"fatal\"",
+		"[severity]\nparse-error = \"warning\"", "disable = [\"AL000\"]"} {
+		_, err := ParseConfig(strings.NewReader(input), name)
+		if err == nil {
+			t.Fatalf("Expected error for %q", input)
+		}
+	}
+}
+
+func TestLintConfig(t *testing.T) {
+	input := `pkgextra=foo
+package() {
+}
+build() {
+}
+snapshot2() {
+}`
+
+	l := newLinter(input)
+	l.cfg = &Config{Metadata: []string{"pkgextra"},
+		Functions: []string{"snapshot2"}}
+	l.lint(checkGlobalVariables, checkUnusedVariables, checkFunctionOrder)
+
+	expMsg(t, l,
+		Msg{2, 1, fmt.Sprintf(wrongFuncOrder, "package", "build")})
+}
+
+func TestSeverity(t *testing.T) {
+	input := `#foo
+foo=bar`
+
+	l := newLinter(input)
+	l.cfg = &Config{Severity: map[string]Severity{
+		checkGlobalVariables.ID: SeverityWarning,
+		checkUnusedVariables.ID: SeverityError,
+	}}
+	diags := l.lint(checkComments, checkGlobalVariables,
+		checkUnusedVariables)
+
+	expected := []Severity{SeverityWarning, SeverityWarning, SeverityError}
+	if len(diags) != len(expected) {
+		t.Fatalf("Expected %d violations, got %d", len(expected), len(diags))
+	}
+	for n, d := range diags {
+		if d.Severity != expected[n] {
+			t.Fatalf("Expected severity %s for %s - got %s",
+				expected[n], d.Check, d.Severity)
+		}
+	}
+
+	invalid := SeverityError + 1
+	if invalid.String() != "Severity(3)" {
+		t.Fatalf("Unexpected name %q for invalid severity", invalid)
+	}
+	if _, err := invalid.MarshalText(); err == nil {
+		t.Fatal("Expected error for invalid severity")
+	}
+}
+
+func TestValue(t *testing.T) {
+	input := `pkgname=foo
+pkgver=1.2.3
+_majorver=${pkgver%.*}
+_basever=${pkgver%%.*}
+_minorver=${pkgver#*.}
+_patchver=${pkgver##*.}
+_url="https://example.org/$pkgname"'/'v$pkgver
+_empty=
+_default=${_empty:-bar}
+_alt=${pkgname:+baz}
+_unset=${_undefined:-bar}
+_carch=${CARCH:-x86_64}
+_flags+=-O2
+source="$_url/$pkgname-$pkgver.tar.gz
+	$pkgname.initd"
+subpackages="$pkgname-doc"
+subpackages="$subpackages $pkgname-dev"
+_cmd=$(uname -m)
+_ref=$_cmd
+if true; then
+	_cond=yes
+fi
+export CFLAGS=-O2
+_env=$CFLAGS
+_str=foo
+_str+=bar
+build() {
+	_local=1
+}`
+
+	values := map[string]string{
+		"pkgname":     "foo",
+		"_majorver":   "1.2",
+		"_basever":    "1",
+		"_minorver":   "2.3",
+		"_patchver":   "3",
+		"_url":        "https://example.org/foo/v1.2.3",
+		"_default":    "bar",
+		"_alt":        "baz",
+		"source":      "https://example.org/foo/v1.2.3/foo-1.2.3.tar.gz\n\tfoo.initd",
+		"subpackages": "foo-doc foo-dev",
+		"_str":        "foobar",
+	}
+
+	abuild, err := Parse(strings.NewReader(input), name)
+	if err != nil {
+		t.Fatal("Parse failed:", err)
+	}
+
+	for varname, expected := range values {
+		value, ok := abuild.Value(varname)
+		if !ok {
+			t.Fatalf("Value of %q couldn't be determined", varname)
+		} else if value != expected {
+			t.Fatalf("Expected %q for %q - got %q", expected, varname, value)
+		}
+	}
+
+	for _, varname := range []string{"_cmd", "_ref", "_cond", "CFLAGS",
+		"_env", "_local", "srcdir", "_unset", "_carch", "_flags"} {
+		if value, ok := abuild.Value(varname); ok {
+			t.Fatalf("Expected %q to be unknown - got %q", varname, value)
+		}
+	}
+}
+
+func TestFormatText(t *testing.T) {
+	var buf bytes.Buffer
+	v := &Diagnostic{Check: "AL007", Name: "param-expansion", File: name,
+		Line: 2, Column: 3, Severity: SeverityWarning, Message: "foo"}
+	if err := FormatText(&buf, v); err != nil {
+		t.Fatal("FormatText failed:", err)
+	}
+
+	expected := name + ":2:3: warning: foo [AL007 param-expansion]\n"
+	if buf.String() != expected {
+		t.Fatalf("Expected %q - got %q", expected, buf.String())
+	}
+}
+
+func TestFormatJSON(t *testing.T) {
+	var buf bytes.Buffer
+
+	l := NewLinter(newLinter(`foo=42`).f, Options{
+		Enabled: map[string]bool{checkGlobalVariables.ID: true},
+	})
+	diags := l.Lint()
+	if len(diags) != 1 {
+		t.Fatalf("Expected 1 violation, got %d", len(diags))
+	}
+	if err := FormatJSON(&buf, diags[0]); err != nil {
+		t.Fatal("FormatJSON failed:", err)
+	}
+
+	var v Diagnostic
+	if err := json.Unmarshal(buf.Bytes(), &v); err != nil {
+		t.Fatal("json.Unmarshal failed:", err)
+	}
+
+	if v.Check != checkGlobalVariables.ID || v.Name != checkGlobalVariables.Name {
+		t.Fatalf("Unexpected check %s (%s)", v.Check, v.Name)
+	}
+	if v.File != name || v.Line != 1 || v.Column != 1 ||
+		v.EndLine != 1 || v.EndColumn != 7 {
+		t.Fatalf("Unexpected position %s:%d:%d-%d:%d", v.File, v.Line,
+			v.Column, v.EndLine, v.EndColumn)
+	}
+	if v.Severity != checkGlobalVariables.Severity {
+		t.Fatalf("Unexpected severity %s", v.Severity)
+	}
+	if v.Format != invalidGlobalVar || len(v.Args) != 1 || v.Args[0] != "foo" {
+		t.Fatalf("Unexpected format %q with arguments %v", v.Format, v.Args)
+	}
+	if v.Message != fmt.Sprintf(invalidGlobalVar, "foo") {
+		t.Fatalf("Unexpected message %q", v.Message)
+	}
+}
+
+func TestParseDiagnostic(t *testing.T) {
+	_, err := Parse(strings.NewReader("foo=bar\n  fi"), name)
+	if err == nil {
+		t.Fatal("Expected parse error")
+	}
+
+	v := ParseDiagnostic(name, err)
+	if v.Check != checkParse.ID || v.Name != checkParse.Name {
+		t.Fatalf("Unexpected check %s (%s)", v.Check, v.Name)
+	}
+	if v.File != name || v.Line != 2 || v.Column != 3 {
+		t.Fatalf("Unexpected position %s:%d:%d", v.File, v.Line, v.Column)
+	}
+	if v.Message != `"fi" can only be used to end an if` {
+		t.Fatalf("Unexpected message %q", v.Message)
+	}
+
+	_, err = os.Open("/nonexistent/APKBUILD")
+	v = ParseDiagnostic("/nonexistent/APKBUILD", err)
+	if v.Line != 0 || v.Message != "no such file or directory" {
+		t.Fatalf("Unexpected violation %v", v)
+	}
+}
@@ -0,0 +1,132 @@
+package abuildlint
+
+import (
+	"mvdan.cc/sh/syntax"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	// isNamePart checks if the given string could be a part of a name
+	// in the shell command language as defined in section 3.235 of
+	// the POSIX base specification.
+	isNamePart = regexp.MustCompile("^[_A-Za-z0-9]+$").MatchString
+
+	// isPkgname checks if the given string is a valid package name,
+	// i.e. if it only consists of lowercase letters, digits and the
+	// characters '.', '_', '+' and '-'.
+	isPkgname = regexp.MustCompile(`^[a-z0-9][a-z0-9._+-]*$`).MatchString
+
+	// isPkgver checks if the given string is a valid package version
+	// according to the version format used by apk-tools.
+	isPkgver = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*[a-z]?` +
+		`(_(alpha|beta|pre|rc|cvs|svn|git|hg|p)[0-9]*)*$`).MatchString
+
+	// isPkgrel checks if the given string is a valid package release,
+	// i.e. a non-negative integer.
+	isPkgrel = regexp.MustCompile(`^[0-9]+$`).MatchString
+
+	// isDepVersion checks if the given string is a valid version
+	// constraint of a dependency, i.e. a package version optionally
+	// followed by a package release.
+	isDepVersion = regexp.MustCompile(`^[0-9]+(\.[0-9]+)*[a-z]?` +
+		`(_(alpha|beta|pre|rc|cvs|svn|git|hg|p)[0-9]*)*(-r[0-9]+)?$`).MatchString
+
+	// isSoname checks if the given string is a valid shared object
+	// name as used by so: dependencies.
+	isSoname = regexp.MustCompile(`^[A-Za-z0-9._+-]+\.so(\.[A-Za-z0-9._+-]+)?$`).MatchString
+
+	// isDepName checks if the given string is a valid name of cmd:,
+	// pc: or other prefixed dependencies.
+	isDepName = regexp.MustCompile(`^[A-Za-z0-9._+-][^\s/:<>=~@]*$`).MatchString
+
+	// isDepPath checks if the given string is a valid absolute file
+	// path as used by file path dependencies.
+	isDepPath = regexp.MustCompile(`^(/[^\s/:<>=~@]+)+$`).MatchString
+
+	// isDepPrefix checks if the given string is a valid prefix of a
+	// dependency name, e.g. so or cmd.
+	isDepPrefix = regexp.MustCompile(`^[a-z][a-z0-9-]*$`).MatchString
+
+	// paramExpRegex matches simple parameter expansions of the form
+	// $name and ${…}.
+	paramExpRegex = regexp.MustCompile(`\$(\{[^}]*\}|[_A-Za-z][_A-Za-z0-9]*)`)
+)
+
+// isSpace reports whether the rune is an ascii space character. This
+// differs from unicode.isSpace which reports whether the rune is a
+// space character as defined by Unicode's White Space property.
+func isSpace(r rune) bool {
+	return r == ' '
+}
+
+// isParamExp reports whether the given parameter expression can be
+// replaced by a short parameter expression.
+func isParamExp(paramExp *syntax.ParamExp) bool {
+	return paramExp.Excl || paramExp.Length || paramExp.Width ||
+		paramExp.Index != nil || paramExp.Slice != nil ||
+		paramExp.Repl != nil || paramExp.Exp != nil
+}
+
+// isPrefixVar reports whether the given string is prefixed with a
+// single ascii underscore character.
+func isPrefixVar(varname string) bool {
+	if len(varname) < 2 {
+		return false
+	}
+
+	return varname[0] == '_' && varname[1] != '_'
+}
+
+// isIncluded reports whether the given string is included in the given
+// string slice.
+func isIncluded(slice []string, str string) bool {
+	for _, s := range slice {
+		if s == str {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isHexDigest reports whether the given string is a hex encoded digest
+// with the given amount of hex characters.
+func isHexDigest(digest string, length int) bool {
+	if len(digest) != length {
+		return false
+	}
+
+	for _, r := range digest {
+		if !strings.ContainsRune("0123456789abcdefABCDEF", r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// filenamePattern returns a regular expression matching the given file
+// name. Parameter expansions contained in the file name match any
+// non-empty string.
+func filenamePattern(fn string) *regexp.Regexp {
+	var pattern string
+	for {
+		loc := paramExpRegex.FindStringIndex(fn)
+		if loc == nil {
+			break
+		}
+
+		pattern += regexp.QuoteMeta(fn[:loc[0]]) + ".+"
+		fn = fn[loc[1]:]
+	}
+
+	return regexp.MustCompile("^" + pattern + regexp.QuoteMeta(fn) + "$")
+}
+
+// exists checks if a file with the given name exists.
+func exists(fn string) bool {
+	_, err := os.Stat(fn)
+	return !os.IsNotExist(err)
+}
@@ -1,32 +1,294 @@
 package main
 
 import (
+	"bytes"
+	"flag"
 	"fmt"
+	"github.com/nmeum/abuild-lint/abuildlint"
+	"io/ioutil"
 	"os"
 	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"text/tabwriter"
 )
 
 const (
 	// File name used for Alpine Linux APKBUILDs.
 	pkgbuildfn = "APKBUILD"
+
+	// Exit status used if style violations were found.
+	exitViolation = 1
+
+	// Exit status used if an APKBUILD couldn't be parsed.
+	exitParseError = 2
+)
+
+var (
+	format     = flag.String("format", "text", "output format for violations")
+	listChecks = flag.Bool("list-checks", false, "list all checks and exit")
+	enable     = flag.String("enable", "", "comma-separated list of checks to perform")
+	disable    = flag.String("disable", "", "comma-separated list of checks to skip")
+	fix        = flag.Bool("fix", false, "fix fixable violations in place")
+	diff       = flag.Bool("diff", false, "print fixes as unified diff instead of applying them")
+	config     = flag.String("config", "", "configuration file to use instead of searching for one")
+	failOn     = flag.String("fail-on", "info", "minimum severity of violations causing a non-zero exit status")
+	recursive  = flag.Bool("r", false, "lint all APKBUILDs in the given directory trees and print a summary")
+	jobs       = flag.Int("j", runtime.NumCPU(), "number of APKBUILDs linted concurrently")
 )
 
+func usage() {
+	fmt.Fprintf(os.Stderr, "Usage: %s [-list-checks] [-config file] [-format text|json] "+
+		"[-enable checks] [-disable checks] [-fail-on severity] "+
+		"[-fix | -diff] [-r] [-j jobs] [aport ...]\n",
+		os.Args[0])
+	flag.PrintDefaults()
+}
+
+func printChecks() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0)
+	for _, c := range abuildlint.Checks() {
+		info := c.Info()
+
+		var fixable string
+		if info.Fixable {
+			fixable = "fixable"
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", info.ID, info.Name,
+			info.Severity, fixable, info.Description)
+	}
+	w.Flush()
+}
+
+// enabledChecks returns the set of checks selected using the given
+// configuration and the -enable and -disable flags. The -enable flag
+// takes precedence over the configuration.
+func enabledChecks(cfg *abuildlint.Config) (map[string]bool, error) {
+	var selected, skipped []string
+	if cfg != nil {
+		selected, skipped = cfg.Enable, cfg.Disable
+	}
+	if *enable != "" {
+		selected, skipped = strings.Split(*enable, ","), nil
+	}
+	skipped = append(skipped, strings.Split(*disable, ",")...)
+
+	return abuildlint.SelectChecks(selected, skipped)
+}
+
+// configCache caches loaded configuration files by file name. It is
+// safe for concurrent use.
+type configCache struct {
+	mu sync.Mutex
+	m  map[string]*abuildlint.Config
+}
+
+// result describes the outcome of linting a single APKBUILD.
+type result struct {
+	stdout     bytes.Buffer             // Output destined for standard output
+	stderr     bytes.Buffer             // Output destined for standard error
+	violations []*abuildlint.Diagnostic // Reported style violations
+	failed     bool                     // Whether a non-zero exit status is required
+	unparsable bool                     // Whether the APKBUILD couldn't be parsed
+	err        error                    // Error preventing further processing
+}
+
+// loadConfig returns the configuration for the APKBUILD with the given
+// file name. If the -config flag wasn't given, the configuration file
+// is searched for in the directory of the APKBUILD and its parents.
+// Loaded configuration files are cached in the given cache.
+func loadConfig(fn string, cache *configCache) (*abuildlint.Config, error) {
+	cfn := *config
+	if cfn == "" {
+		cfn = abuildlint.FindConfig(filepath.Dir(fn))
+		if cfn == "" {
+			return nil, nil
+		}
+	}
+
+	cache.mu.Lock()
+	defer cache.mu.Unlock()
+
+	cfg, ok := cache.m[cfn]
+	if ok {
+		return cfg, nil
+	}
+
+	cfg, err := abuildlint.LoadConfig(cfn)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.m[cfn] = cfg
+	return cfg, nil
+}
+
+// lintFile parses and lints the APKBUILD with the given file name and
+// applies or prints fixes if requested. All output is buffered in the
+// returned result.
+func lintFile(fn string, formatter abuildlint.Formatter,
+	threshold abuildlint.Severity, configs *configCache) *result {
+	r := &result{}
+
+	abuild, err := parseFile(fn)
+	if err != nil {
+		v := abuildlint.ParseDiagnostic(fn, err)
+		r.violations = append(r.violations, v)
+		formatter(&r.stdout, v)
+		r.unparsable = true
+		return r
+	}
+
+	cfg, err := loadConfig(fn, configs)
+	if err != nil {
+		r.err = fmt.Errorf("Couldn't load configuration: %s", err)
+		return r
+	}
+
+	enabled, err := enabledChecks(cfg)
+	if err != nil {
+		r.err = fmt.Errorf("Couldn't select checks: %s", err)
+		return r
+	}
+
+	linter := abuildlint.NewLinter(abuild, abuildlint.Options{
+		Config:  cfg,
+		Enabled: enabled,
+		Fix:     *fix || *diff,
+	})
+
+	r.violations = linter.Lint()
+	for _, v := range r.violations {
+		formatter(&r.stdout, v)
+		if v.Severity >= threshold {
+			r.failed = true
+		}
+	}
+
+	src, fixed := linter.Fix()
+	if !fixed {
+		return r
+	}
+
+	if *diff {
+		abuildlint.WriteDiff(&r.stdout, fn, abuild.Source(), src)
+		r.failed = true
+	} else if err := writeFile(fn, src); err != nil {
+		fmt.Fprintf(&r.stderr, "Couldn't write %q: %s.\n", fn, err)
+		r.failed = true
+	}
+
+	return r
+}
+
+// findAPKBUILDs returns the file names of all APKBUILDs contained in
+// the directory tree rooted at the given directory in lexical order.
+// Hidden directories are skipped.
+func findAPKBUILDs(root string) ([]string, error) {
+	var fns []string
+	err := filepath.Walk(root, func(fn string, fi os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if fi.IsDir() {
+			if fn != root && strings.HasPrefix(fi.Name(), ".") {
+				return filepath.SkipDir
+			}
+		} else if fi.Name() == pkgbuildfn && fi.Mode().IsRegular() {
+			fns = append(fns, fn)
+		}
+
+		return nil
+	})
+
+	return fns, err
+}
+
+// parseFile reads and parses the APKBUILD with the given file name.
+func parseFile(fn string) (*abuildlint.APKBUILD, error) {
+	file, err := os.Open(fn)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return abuildlint.Parse(file, fn)
+}
+
+// writeFile replaces the content of the existing file with the given
+// name while preserving its permissions.
+func writeFile(fn string, data []byte) error {
+	fi, err := os.Stat(fn)
+	if err != nil {
+		return err
+	}
+
+	return ioutil.WriteFile(fn, data, fi.Mode())
+}
+
 func main() {
+	flag.Usage = usage
+	flag.Parse()
+
+	if *listChecks {
+		printChecks()
+		return
+	}
+
+	formatter, ok := abuildlint.Formatters[*format]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Unknown output format %q.\n", *format)
+		os.Exit(1)
+	}
+
+	threshold, err := abuildlint.ParseSeverity(*failOn)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid -fail-on value: %s.\n", err)
+		os.Exit(1)
+	}
+
+	if *jobs < 1 {
+		fmt.Fprintf(os.Stderr, "Invalid -j value: must be at least 1.\n")
+		os.Exit(1)
+	}
+
+	if _, err := enabledChecks(nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Couldn't select checks: %s.\n", err)
+		os.Exit(1)
+	}
+
 	var fns []string
-	if len(os.Args) <= 1 {
-		if !Exists(pkgbuildfn) {
+	if *recursive {
+		roots := flag.Args()
+		if len(roots) == 0 {
+			roots = []string{"."}
+		}
+
+		for _, root := range roots {
+			found, err := findAPKBUILDs(root)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Couldn't search %q: %s.\n", root, err)
+				os.Exit(1)
+			}
+			fns = append(fns, found...)
+		}
+	} else if flag.NArg() == 0 {
+		if !exists(pkgbuildfn) {
 			fmt.Fprintf(os.Stderr, "%q doesn't exists in current directory.\n", pkgbuildfn)
 			os.Exit(1)
 		}
 
 		fns = []string{pkgbuildfn}
 	} else {
-		for _, arg := range os.Args[1:] {
-			if IsDir(arg) {
+		for _, arg := range flag.Args() {
+			if isDir(arg) {
 				arg = filepath.Join(arg, pkgbuildfn)
 			}
 
-			if !Exists(arg) {
+			if !exists(arg) {
 				fmt.Fprintf(os.Stderr, "%q doesn't exist.\n", arg)
 				os.Exit(1)
 			}
@@ -35,28 +297,51 @@ func main() {
 		}
 	}
 
-	var abuilds []*APKBUILD
-	for _, fn := range fns {
-		file, err := os.Open(fn)
-		if err != nil {
-			panic(err)
-		}
+	// Each APKBUILD is linted by one of the workers, results are
+	// processed in input order to keep the output deterministic.
+	results := make([]chan *result, len(fns))
+	for i := range results {
+		results[i] = make(chan *result, 1)
+	}
 
-		abuild, err := Parse(file, fn)
-		if err != nil {
-			panic(err)
+	queue := make(chan int)
+	go func() {
+		for i := range fns {
+			queue <- i
 		}
+		close(queue)
+	}()
 
-		abuilds = append(abuilds, abuild)
-		file.Close()
+	configs := &configCache{m: make(map[string]*abuildlint.Config)}
+	for n := 0; n < *jobs; n++ {
+		go func() {
+			for i := range queue {
+				results[i] <- lintFile(fns[i], formatter, threshold, configs)
+			}
+		}()
 	}
 
 	exitStatus := 0
-	for _, abuild := range abuilds {
-		linter := Linter{f: abuild, w: os.Stdout}
-		if linter.Lint() {
-			exitStatus = 1
+	summary := newSummary()
+	for i, fn := range fns {
+		r := <-results[i]
+		r.stdout.WriteTo(os.Stdout)
+		r.stderr.WriteTo(os.Stderr)
+		if r.err != nil {
+			fmt.Fprintf(os.Stderr, "%s.\n", r.err)
+			os.Exit(1)
+		}
+
+		if r.unparsable {
+			exitStatus = exitParseError
+		} else if r.failed && exitStatus == 0 {
+			exitStatus = exitViolation
 		}
+		summary.Add(fn, r.violations)
+	}
+
+	if *recursive {
+		summary.Write(os.Stderr)
 	}
 	os.Exit(exitStatus)
 }
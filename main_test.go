@@ -0,0 +1,36 @@
+package main
+
+import (
+	"bytes"
+	"github.com/nmeum/abuild-lint/abuildlint"
+	"testing"
+)
+
+func TestSummary(t *testing.T) {
+	s := newSummary()
+	s.Add("aports/main/foo/APKBUILD", []*abuildlint.Diagnostic{
+		{Check: "AL003", Name: "global-variable"},
+		{Check: "AL001", Name: "comment-prefix"},
+	})
+	s.Add("aports/main/bar/APKBUILD", nil)
+	s.Add("aports/testing/baz/APKBUILD", []*abuildlint.Diagnostic{
+		{Check: "AL003", Name: "global-variable"},
+	})
+
+	var buf bytes.Buffer
+	if err := s.Write(&buf); err != nil {
+		t.Fatal("Write failed:", err)
+	}
+
+	expected := `REPOSITORY  APKBUILDS  FAILING  VIOLATIONS
+main        2          1        2
+testing     1          1        1
+
+CHECK  NAME             VIOLATIONS
+AL001  comment-prefix   1
+AL003  global-variable  2
+`
+	if buf.String() != expected {
+		t.Fatalf("Expected %q - got %q", expected, buf.String())
+	}
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"fmt"
+	"github.com/nmeum/abuild-lint/abuildlint"
+	"io"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+)
+
+// repoSummary contains statistics about a single repository.
+type repoSummary struct {
+	n int // Amount of linted APKBUILDs
+	f int // Amount of APKBUILDs with style violations
+	v int // Amount of style violations
+}
+
+// summary collects statistics about the style violations found in
+// APKBUILDs of multiple repositories.
+type summary struct {
+	repos  map[string]*repoSummary
+	checks map[string]int
+	names  map[string]string
+}
+
+// newSummary returns a new empty summary.
+func newSummary() *summary {
+	return &summary{
+		repos:  make(map[string]*repoSummary),
+		checks: make(map[string]int),
+		names:  make(map[string]string),
+	}
+}
+
+// Add adds the style violations found in the APKBUILD with the given
+// file name to the summary.
+func (s *summary) Add(fn string, violations []*abuildlint.Diagnostic) {
+	repo := repository(fn)
+	r, ok := s.repos[repo]
+	if !ok {
+		r = &repoSummary{}
+		s.repos[repo] = r
+	}
+
+	r.n++
+	r.v += len(violations)
+	if len(violations) > 0 {
+		r.f++
+	}
+
+	for _, v := range violations {
+		s.checks[v.Check]++
+		s.names[v.Check] = v.Name
+	}
+}
+
+// Write writes the summary as a human readable table per repository
+// and per check to the given writer.
+func (s *summary) Write(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+
+	fmt.Fprintln(tw, "REPOSITORY\tAPKBUILDS\tFAILING\tVIOLATIONS")
+	var repos []string
+	for repo := range s.repos {
+		repos = append(repos, repo)
+	}
+	sort.Strings(repos)
+
+	for _, repo := range repos {
+		r := s.repos[repo]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\n", repo, r.n, r.f, r.v)
+	}
+
+	fmt.Fprintln(tw, "\nCHECK\tNAME\tVIOLATIONS")
+	var ids []string
+	for id := range s.checks {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		fmt.Fprintf(tw, "%s\t%s\t%d\n", id, s.names[id], s.checks[id])
+	}
+
+	return tw.Flush()
+}
+
+// repository returns the name of the repository containing the APKBUILD
+// with the given file name, i.e. the name of the directory containing
+// the directory of the APKBUILD.
+func repository(fn string) string {
+	dir, err := filepath.Abs(filepath.Dir(fn))
+	if err != nil {
+		dir = filepath.Dir(fn)
+	}
+
+	return filepath.Base(filepath.Dir(dir))
+}